@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qubetics/qubetics-go-sdk/config"
+)
+
+// NewConfigCmd creates and returns a new Cobra command for configuration sub-commands.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "config",
+		Short:        "Sub-commands for managing the configuration file",
+		SilenceUsage: true,
+	}
+
+	// Add sub-commands for configuration management
+	cmd.AddCommand(
+		configInitCmd(),
+	)
+
+	return cmd
+}
+
+// configInitCmd writes the default configuration, with comments documenting each field, to a file.
+func configInitCmd() *cobra.Command {
+	// Declare variables for flags
+	force := false
+
+	cmd := &cobra.Command{
+		Use:   "init [file]",
+		Short: "Write the default configuration to a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Refuse to overwrite an existing file unless forced
+			if _, err := os.Stat(args[0]); err == nil && !force {
+				return fmt.Errorf("file %s already exists", args[0])
+			}
+
+			// Write the default configuration, including registered sections
+			if err := config.DefaultConfig().WriteToFile(args[0]); err != nil {
+				return fmt.Errorf("failed to write config: %w", err)
+			}
+
+			cmd.Printf("Configuration written to %s\n", args[0])
+			return nil
+		},
+	}
+
+	// Add flags to the command
+	cmd.Flags().BoolVar(&force, "force", force, "overwrite the file if it already exists")
+
+	return cmd
+}
+
+// loadKeyringProfile loads the configuration file with the named profile applied and copies its keyring values
+// into cfg. Values set explicitly with keyring flags take precedence over the file. It does nothing when no
+// configuration file is given, and returns an error if a profile is selected without one.
+func loadKeyringProfile(cmd *cobra.Command, configFile, profile string, cfg *config.KeyringConfig) error {
+	if configFile == "" {
+		if profile != "" {
+			return fmt.Errorf("profile %s requires a configuration file", profile)
+		}
+
+		return nil
+	}
+
+	c, err := config.LoadProfile(configFile, profile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cmd.Flags().Changed("keyring.backend") {
+		cfg.Backend = c.Keyring.Backend
+	}
+	if !cmd.Flags().Changed("keyring.name") {
+		cfg.Name = c.Keyring.Name
+	}
+
+	return nil
+}
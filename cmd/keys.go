@@ -22,11 +22,20 @@ func NewKeysCmd(cfg *config.KeyringConfig) *cobra.Command {
 	// Initialize a base client
 	c := core.NewClient()
 
+	// Declare variables for flags
+	configFile := ""
+	profile := ""
+
 	cmd := &cobra.Command{
 		Use:          "keys",
 		Short:        "Sub-commands for managing keys",
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Apply the keyring values of the configuration file and profile, if any
+			if err := loadKeyringProfile(cmd, configFile, profile, cfg); err != nil {
+				return err
+			}
+
 			// Validate the provided configuration
 			if err := cfg.Validate(); err != nil {
 				return fmt.Errorf("failed to validate config: %w", err)
@@ -51,6 +60,8 @@ func NewKeysCmd(cfg *config.KeyringConfig) *cobra.Command {
 
 	// Configure persistent flags for the command
 	cfg.SetForFlags(cmd.PersistentFlags())
+	config.SetProfileForFlags(cmd.PersistentFlags(), &profile)
+	cmd.PersistentFlags().StringVar(&configFile, "config", configFile, "path to the configuration file holding the profiles")
 
 	return cmd
 }
@@ -67,6 +78,11 @@ func keysAddCmd(c *core.Client) *cobra.Command {
 		Short: "Add a new key with the specified name and optional mnemonic",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate the output format before making any changes
+			if err := utils.ValidateOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
 			// Check if the key already exists
 			ok, err := c.HasKey(args[0])
 			if err != nil {
@@ -145,7 +161,7 @@ func keysAddCmd(c *core.Client) *cobra.Command {
 
 	// Bind flags to variables
 	cmd.Flags().StringVar(&hdPath, "hd-path", hdPath, "full absolute hd path of the bip44 params")
-	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json or text)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json, table, text or yaml)")
 
 	return cmd
 }
@@ -200,6 +216,11 @@ func keysListCmd(c *core.Client) *cobra.Command {
 		Use:   "list",
 		Short: "List all available keys",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate the output format
+			if err := utils.ValidateOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
 			// Fetch the list of keys from the client
 			keys, err := c.Keys()
 			if err != nil {
@@ -222,7 +243,7 @@ func keysListCmd(c *core.Client) *cobra.Command {
 	}
 
 	// Bind flags to variables
-	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json or text)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json, table, text or yaml)")
 
 	return cmd
 }
@@ -237,6 +258,11 @@ func keysShowCmd(c *core.Client) *cobra.Command {
 		Short: "Show details of the key with the specified name",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate the output format
+			if err := utils.ValidateOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
 			// Retrieve key details from the client
 			key, err := c.Key(args[0])
 			if err != nil {
@@ -259,7 +285,7 @@ func keysShowCmd(c *core.Client) *cobra.Command {
 	}
 
 	// Bind flags to variables
-	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json or text)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json, table, text or yaml)")
 
 	return cmd
 }
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/qubetics/qubetics-go-sdk/types"
+	"github.com/qubetics/qubetics-go-sdk/utils"
+	"github.com/qubetics/qubetics-go-sdk/v2ray"
+	"github.com/qubetics/qubetics-go-sdk/wireguard"
+)
+
+// NewVPNCmd creates and returns a new Cobra command for VPN server sub-commands.
+func NewVPNCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "vpn",
+		Short:        "Sub-commands for managing VPN servers",
+		SilenceUsage: true,
+	}
+
+	// Add sub-commands for VPN server management
+	cmd.AddCommand(
+		vpnStatsCmd(),
+		vpnValidateCmd(),
+	)
+
+	return cmd
+}
+
+// readServerConfig reads a TOML file and decodes it into the server configuration.
+func readServerConfig(name string, cfg interface{}) error {
+	buf, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := toml.Unmarshal(buf, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal file: %w", err)
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           cfg,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if err := dec.Decode(raw); err != nil {
+		return fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return nil
+}
+
+// vpnValidateCmd checks a VPN server configuration file without starting the server.
+func vpnValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [type] [file]",
+		Short: "Validate a VPN server configuration without starting the server",
+		Long: "Validate a v2ray or wireguard server configuration, render its template, check that the " +
+			"required binaries are available and that its ports are free, reporting all problems at once.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Decode the configuration for the service type and validate it
+			var err error
+			switch types.ServiceTypeFromString(args[0]) {
+			case types.ServiceTypeV2Ray:
+				cfg := &v2ray.ServerConfig{}
+				if err := readServerConfig(args[1], cfg); err != nil {
+					return err
+				}
+
+				err = v2ray.NewServer().ValidateConfig(cfg)
+			case types.ServiceTypeWireGuard:
+				cfg := &wireguard.ServerConfig{}
+				if err := readServerConfig(args[1], cfg); err != nil {
+					return err
+				}
+
+				err = wireguard.NewServer().ValidateConfig(cfg)
+			default:
+				return fmt.Errorf("%w %q", types.ErrUnsupportedServiceType, args[0])
+			}
+
+			if err != nil {
+				return fmt.Errorf("configuration is invalid:\n%w", err)
+			}
+
+			cmd.Println("Configuration is valid")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// peerStatistics holds the statistics of VPN peers for output.
+type peerStatistics []*types.PeerStatistic
+
+// Table implements utils.Tabler, printing the byte counts in binary units.
+func (items peerStatistics) Table() *utils.Table {
+	t := utils.NewTable("key", "connected", "download", "upload", "endpoint")
+	for _, item := range items {
+		t.AddRow(
+			item.Key,
+			strconv.FormatBool(item.Connected),
+			utils.FormatBytes(item.DownloadBytes),
+			utils.FormatBytes(item.UploadBytes),
+			item.Endpoint,
+		)
+	}
+
+	return t
+}
+
+// vpnStatsCmd shows the traffic statistics of the peers of a running WireGuard server.
+func vpnStatsCmd() *cobra.Command {
+	// Declare variables for flags
+	outputFormat := "table"
+
+	cmd := &cobra.Command{
+		Use:   "stats [name]",
+		Short: "Show the traffic statistics of the peers of a running WireGuard server",
+		Long: "Show the traffic statistics of the peers of the running WireGuard server with the given interface " +
+			"name. The table output prints byte counts in binary units, while the other formats print them in bytes.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate the output format
+			if err := utils.ValidateOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
+			// Retrieve the statistics of the peers from the interface
+			items, err := wireguard.NewServer().WithName(args[0]).PeerStatistics(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get peer statistics: %w", err)
+			}
+
+			// Output the statistics in the specified format
+			if err := utils.Writeln(cmd.OutOrStdout(), peerStatistics(items), outputFormat); err != nil {
+				return fmt.Errorf("failed to write to output: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	// Bind flags to variables
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormat, "format for command output (json, table, text or yaml)")
+
+	return cmd
+}
@@ -8,15 +8,26 @@ import (
 
 // Config represents the overall configuration structure.
 type Config struct {
+	Version uint `mapstructure:"version"` // Version is the schema version of the configuration.
+
+	GeoIP   *GeoIPConfig   `mapstructure:"geoip"`   // GeoIP contains geoip configuration.
 	Keyring *KeyringConfig `mapstructure:"keyring"` // Keyring contains keyring configuration.
 	Log     *LogConfig     `mapstructure:"log"`     // Log contains logging configuration.
 	Query   *QueryConfig   `mapstructure:"query"`   // Query contains query configuration.
 	RPC     *RPCConfig     `mapstructure:"rpc"`     // RPC contains RPC configuration.
 	Tx      *TxConfig      `mapstructure:"tx"`      // Tx contains transaction configuration.
+
+	Sections map[string]Section `mapstructure:"-"` // Sections contains the registered configuration sections keyed by name.
 }
 
 // Validate validates the entire configuration.
 func (c *Config) Validate() error {
+	if c.Version > CurrentVersion {
+		return fmt.Errorf("version %d is newer than the supported version %d", c.Version, CurrentVersion)
+	}
+	if err := c.GeoIP.Validate(); err != nil {
+		return fmt.Errorf("invalid geoip: %w", err)
+	}
 	if err := c.Keyring.Validate(); err != nil {
 		return fmt.Errorf("invalid keyring: %w", err)
 	}
@@ -32,26 +43,40 @@ func (c *Config) Validate() error {
 	if err := c.Tx.Validate(); err != nil {
 		return fmt.Errorf("invalid tx: %w", err)
 	}
+	for _, name := range c.SectionNames() {
+		if err := c.Sections[name].Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
 
 	return nil
 }
 
 // SetForFlags adds configuration flags to the specified FlagSet.
 func (c *Config) SetForFlags(f *pflag.FlagSet) {
+	c.GeoIP.SetForFlags(f)
 	c.Keyring.SetForFlags(f)
 	c.Log.SetForFlags(f)
 	c.Query.SetForFlags(f)
 	c.RPC.SetForFlags(f)
 	c.Tx.SetForFlags(f)
+	for _, name := range c.SectionNames() {
+		c.Sections[name].SetForFlags(f)
+	}
 }
 
 // DefaultConfig returns a configuration instance with default values.
 func DefaultConfig() *Config {
 	return &Config{
+		Version: CurrentVersion,
+
+		GeoIP:   DefaultGeoIPConfig(),
 		Keyring: DefaultKeyringConfig(),
 		Log:     DefaultLogConfig(),
 		Query:   DefaultQueryConfig(),
 		RPC:     DefaultRPCConfig(),
 		Tx:      DefaultTxConfig(),
+
+		Sections: defaultSections(),
 	}
 }
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Embed the template file for the configuration.
+//
+//go:embed *.tmpl
+var fs embed.FS
+
+// tomlString encodes the string as a TOML basic string.
+func tomlString(s string) string {
+	return strconv.Quote(s)
+}
+
+// tomlList encodes the strings as a TOML array of basic strings.
+func tomlList(items []string) string {
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, tomlString(item))
+	}
+
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// tomlValue encodes a scalar or string slice value as TOML, returning false for unsupported kinds.
+func tomlValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return tomlString(v.String()), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			return tomlList(v.Interface().([]string)), true
+		}
+	}
+
+	return "", false
+}
+
+// sectionFields returns the "key = value" lines of a registered section, using the mapstructure tags of its
+// fields as keys. Fields without a tag, tagged "-", or of unsupported kinds such as nested structs are skipped.
+func (c *Config) sectionFields(name string) []string {
+	v := reflect.Indirect(reflect.ValueOf(c.Section(name)))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var lines []string
+	for i := 0; i < v.NumField(); i++ {
+		key := strings.Split(v.Type().Field(i).Tag.Get("mapstructure"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		if value, ok := tomlValue(v.Field(i)); ok {
+			lines = append(lines, fmt.Sprintf("%s = %s", key, value))
+		}
+	}
+
+	return lines
+}
+
+// WriteToFile writes the configuration to a TOML file with comments documenting each field.
+// Registered sections are written after the built-in ones, without comments.
+func (c *Config) WriteToFile(name string) error {
+	// Read the configuration template file.
+	text, err := fs.ReadFile("config.toml.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New("config").Funcs(template.FuncMap{
+		"list":    tomlList,
+		"quote":   tomlString,
+		"section": c.sectionFields,
+	}).Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	// Execute the template and write it to the specified file.
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	if err := os.WriteFile(name, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// readRawFile reads a TOML configuration file into a map of its raw values.
+func readRawFile(name string) (map[string]interface{}, error) {
+	buf, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := toml.Unmarshal(buf, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal file: %w", err)
+	}
+
+	return raw, nil
+}
+
+// ReadFromFile reads a TOML configuration file and upgrades it to the current schema with Migrate,
+// so that files written by older versions load with the missing fields set to their defaults.
+func ReadFromFile(name string) (*Config, error) {
+	raw, err := readRawFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := Migrate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// GeoIPConfig defines the configuration for resolving IP addresses into locations.
+type GeoIPConfig struct {
+	DBPath    string `mapstructure:"db_path"`    // DBPath is the path of the MaxMind database, used by the mmdb provider.
+	Provider  string `mapstructure:"provider"`   // Provider is the name of the GeoIP provider (geojs, ip_api or mmdb).
+	ProxyAddr string `mapstructure:"proxy_addr"` // ProxyAddr is an optional proxy URL for the HTTP providers.
+	Timeout   string `mapstructure:"timeout"`    // Timeout is the duration for GeoIP requests.
+}
+
+// GetDBPath returns the path of the MaxMind database.
+func (c *GeoIPConfig) GetDBPath() string {
+	return c.DBPath
+}
+
+// GetProvider returns the name of the GeoIP provider.
+func (c *GeoIPConfig) GetProvider() string {
+	return c.Provider
+}
+
+// GetProxyAddr returns the proxy URL for the HTTP providers.
+func (c *GeoIPConfig) GetProxyAddr() string {
+	return c.ProxyAddr
+}
+
+// GetTimeout returns the maximum duration for a GeoIP request.
+func (c *GeoIPConfig) GetTimeout() time.Duration {
+	v, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Validate ensures the GeoIP configuration is valid.
+func (c *GeoIPConfig) Validate() error {
+	// Check if the provider is valid.
+	validProviders := map[string]bool{
+		"geojs":  true,
+		"ip_api": true,
+		"mmdb":   true,
+	}
+	if !validProviders[c.Provider] {
+		return errors.New("provider must be one of: geojs, ip_api, mmdb")
+	}
+
+	// Ensure DBPath is set for the mmdb provider.
+	if c.Provider == "mmdb" && c.DBPath == "" {
+		return errors.New("db_path cannot be empty for the mmdb provider")
+	}
+
+	// Validate the proxy URL if provided.
+	if c.ProxyAddr != "" {
+		if _, err := url.Parse(c.ProxyAddr); err != nil {
+			return fmt.Errorf("invalid proxy_addr: %w", err)
+		}
+	}
+
+	// Validate that Timeout is a valid time.Duration.
+	if _, err := time.ParseDuration(c.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+
+	return nil
+}
+
+// SetForFlags adds geoip configuration flags to the specified FlagSet.
+func (c *GeoIPConfig) SetForFlags(f *pflag.FlagSet) {
+	f.StringVar(&c.DBPath, "geoip.db-path", c.DBPath, "path of the maxmind database for the mmdb provider")
+	f.StringVar(&c.Provider, "geoip.provider", c.Provider, "geoip provider to use (geojs, ip_api, mmdb)")
+	f.StringVar(&c.ProxyAddr, "geoip.proxy-addr", c.ProxyAddr, "proxy url for the geoip http providers")
+	f.StringVar(&c.Timeout, "geoip.timeout", c.Timeout, "timeout for the geoip requests (e.g., 5s, 500ms)")
+}
+
+// DefaultGeoIPConfig creates a GeoIPConfig with default values.
+func DefaultGeoIPConfig() *GeoIPConfig {
+	return &GeoIPConfig{
+		DBPath:    "",
+		Provider:  "ip_api",
+		ProxyAddr: "",
+		Timeout:   "5s",
+	}
+}
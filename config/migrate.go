@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// CurrentVersion is the version of the configuration schema produced by DefaultConfig.
+// Configuration files without a version field are treated as version 0.
+const CurrentVersion uint = 1
+
+// migration upgrades a raw configuration from one schema version to the next.
+type migration func(raw map[string]interface{}) error
+
+// migrations holds the migration from each schema version to the next, indexed by the source version.
+var migrations = []migration{
+	migrateV0ToV1,
+}
+
+// migrateV0ToV1 upgrades unversioned configurations to version 1. The first versioned schema has the same keys
+// as unversioned files, so Migrate only stamps the version and fills the missing fields with their defaults.
+func migrateV0ToV1(map[string]interface{}) error {
+	return nil
+}
+
+// copyMap returns a deep copy of the nested maps in raw, so that migrations do not modify the caller's data.
+func copyMap(raw map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		if sub, ok := v.(map[string]interface{}); ok {
+			v = copyMap(sub)
+		}
+		m[k] = v
+	}
+
+	return m
+}
+
+// decode decodes the raw values into the output, converting compatible types such as
+// numbers parsed as floats. Lists and maps in the output are replaced rather than merged.
+func decode(input, output interface{}) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           output,
+		WeaklyTypedInput: true,
+		ZeroFields:       true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return dec.Decode(input)
+}
+
+// Migrate upgrades a raw configuration, as read from a configuration file, to the current schema.
+// It applies the migrations between the file's version and CurrentVersion, then decodes the values
+// over DefaultConfig so that fields added since the file was written take their default values.
+// Registered sections are decoded from the keys matching their names.
+func Migrate(old map[string]interface{}) (*Config, error) {
+	raw := copyMap(old)
+
+	// Determine the schema version of the configuration.
+	var version uint
+	if v, ok := raw["version"]; ok {
+		if err := decode(v, &version); err != nil {
+			return nil, fmt.Errorf("invalid version: %w", err)
+		}
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("version %d is newer than the supported version %d", version, CurrentVersion)
+	}
+
+	// Apply each migration in turn up to the current version.
+	for ; version < CurrentVersion; version++ {
+		if err := migrations[version](raw); err != nil {
+			return nil, fmt.Errorf("failed to migrate from version %d: %w", version, err)
+		}
+	}
+	raw["version"] = CurrentVersion
+
+	// Decode the migrated values over the defaults.
+	c := DefaultConfig()
+	if err := decode(raw, c); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	for _, name := range c.SectionNames() {
+		if v, ok := raw[name]; ok {
+			if err := decode(v, c.Sections[name]); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+			}
+		}
+	}
+
+	return c, nil
+}
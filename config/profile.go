@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// profilesKey is the key of the table holding the named profiles in a configuration file.
+const profilesKey = "profiles"
+
+// mergeMaps merges src over dst recursively, so that nested tables in src override only the keys they set.
+func mergeMaps(dst, src map[string]interface{}) {
+	for k, v := range src {
+		sub, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+
+		if cur, ok := dst[k].(map[string]interface{}); ok {
+			mergeMaps(cur, sub)
+		} else {
+			dst[k] = copyMap(sub)
+		}
+	}
+}
+
+// LoadProfile reads a TOML configuration file and returns its configuration with the named profile applied.
+// The file holds the shared values at the top level and each profile as a table under "profiles", for example
+// [profiles.testnet.rpc] overriding the rpc values for the "testnet" profile. The profile is merged over the
+// shared values, which are merged over DefaultConfig; an empty profile name uses the shared values only.
+// It returns an error if the named profile does not exist.
+func LoadProfile(path, profile string) (*Config, error) {
+	raw, err := readRawFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Separate the profiles from the shared values.
+	profiles, ok := raw[profilesKey].(map[string]interface{})
+	if _, exists := raw[profilesKey]; exists && !ok {
+		return nil, fmt.Errorf("%s must be a table", profilesKey)
+	}
+	delete(raw, profilesKey)
+
+	// Merge the selected profile over the shared values.
+	if profile != "" {
+		values, ok := profiles[profile].(map[string]interface{})
+		if !ok {
+			names := make([]string, 0, len(profiles))
+			for name := range profiles {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+			return nil, fmt.Errorf("profile %s does not exist (available: %s)", profile, strings.Join(names, ", "))
+		}
+
+		mergeMaps(raw, values)
+	}
+
+	// Upgrade the values to the current schema and merge them over the defaults.
+	c, err := Migrate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	return c, nil
+}
+
+// SetProfileForFlags adds the flag selecting the configuration profile passed to LoadProfile.
+func SetProfileForFlags(f *pflag.FlagSet, profile *string) {
+	f.StringVar(profile, "profile", *profile, "name of the configuration profile to use (e.g., mainnet, testnet)")
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// RedactedValue replaces sensitive values in redacted configurations.
+const RedactedValue = "[REDACTED]"
+
+// SectionRedactor is implemented by sections that hold sensitive values, such as private keys.
+// Config.Redacted uses it to mask registered sections; sections that do not implement it are kept as is.
+type SectionRedactor interface {
+	RedactedSection() Section // RedactedSection returns a copy of the section with sensitive values masked.
+}
+
+// RedactString returns RedactedValue if s is not empty, and an empty string otherwise.
+func RedactString(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return RedactedValue
+}
+
+// RedactURL masks the password of the user information in a URL, returning s unchanged if it is not a valid URL.
+func RedactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+
+	return u.Redacted()
+}
+
+// Redacted returns a copy of the configuration that is safe to log, with credentials in addresses
+// masked and registered sections redacted through SectionRedactor.
+func (c *Config) Redacted() *Config {
+	v := *c
+
+	if c.GeoIP != nil {
+		geoip := *c.GeoIP
+		geoip.ProxyAddr = RedactURL(geoip.ProxyAddr)
+		v.GeoIP = &geoip
+	}
+
+	// The input reader may be connected to a terminal or hold a passphrase, so it is dropped.
+	if c.Keyring != nil {
+		keyring := *c.Keyring
+		keyring.Input = nil
+		v.Keyring = &keyring
+	}
+
+	if c.RPC != nil {
+		rpc := *c.RPC
+		rpc.Addrs = make([]string, len(c.RPC.Addrs))
+		for i, addr := range c.RPC.Addrs {
+			rpc.Addrs[i] = RedactURL(addr)
+		}
+		v.RPC = &rpc
+	}
+
+	if c.Sections != nil {
+		v.Sections = make(map[string]Section, len(c.Sections))
+		for name, s := range c.Sections {
+			if r, ok := s.(SectionRedactor); ok {
+				s = r.RedactedSection()
+			}
+			v.Sections[name] = s
+		}
+	}
+
+	return &v
+}
+
+// String returns the redacted configuration encoded as JSON.
+func (c *Config) String() string {
+	buf, err := json.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(buf)
+}
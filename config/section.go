@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// Section is a named group of configuration values that can be added to Config without changing
+// its struct. Sections are registered with RegisterSection along with a constructor for their defaults.
+type Section interface {
+	Validate() error              // Validate ensures the section values are valid.
+	SetForFlags(f *pflag.FlagSet) // SetForFlags adds the section flags to the specified FlagSet.
+}
+
+// builtinSections lists the names of the sections that are fields of Config.
+var builtinSections = map[string]bool{
+	"geoip":   true,
+	"keyring": true,
+	"log":     true,
+	"query":   true,
+	"rpc":     true,
+	"tx":      true,
+}
+
+var (
+	sectionsMu      sync.RWMutex
+	sectionDefaults = make(map[string]func() Section)
+)
+
+// RegisterSection registers a section under the name with a constructor returning its default values.
+// Sections registered before DefaultConfig is called are included in the returned Config.
+// It panics if the name is empty, used by a built-in section, or already registered.
+func RegisterSection(name string, defaultFn func() Section) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+
+	if name == "" {
+		panic("section name cannot be empty")
+	}
+	if builtinSections[name] {
+		panic(fmt.Sprintf("section %s is built-in", name))
+	}
+	if _, ok := sectionDefaults[name]; ok {
+		panic(fmt.Sprintf("section %s is already registered", name))
+	}
+
+	sectionDefaults[name] = defaultFn
+}
+
+// defaultSections returns the default values of all registered sections.
+func defaultSections() map[string]Section {
+	sectionsMu.RLock()
+	defer sectionsMu.RUnlock()
+
+	m := make(map[string]Section, len(sectionDefaults))
+	for name, fn := range sectionDefaults {
+		m[name] = fn()
+	}
+
+	return m
+}
+
+// SectionNames returns the sorted names of the registered sections held by the configuration.
+func (c *Config) SectionNames() []string {
+	names := make([]string, 0, len(c.Sections))
+	for name := range c.Sections {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Section returns the registered section with the given name, or nil if it does not exist.
+// Downstream applications decode the section's values into the returned value, for example
+// with viper.UnmarshalKey(name, c.Section(name)), and type assert it to their concrete type.
+func (c *Config) Section(name string) Section {
+	return c.Sections[name]
+}
+
+// SetSection sets the section with the given name, replacing any existing value.
+func (c *Config) SetSection(name string, s Section) {
+	if c.Sections == nil {
+		c.Sections = make(map[string]Section)
+	}
+
+	c.Sections[name] = s
+}
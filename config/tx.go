@@ -22,6 +22,7 @@ type TxConfig struct {
 	QueryRetryAttempts     uint    `mapstructure:"query_retry_attempts"`     // Number of times to retry querying a transaction.
 	QueryRetryDelay        string  `mapstructure:"query_retry_delay"`        // Delay between query retries.
 	SimulateAndExecute     bool    `mapstructure:"simulate_and_execute"`     // SimulateAndExecute indicates whether to simulate the transaction before execution.
+	SkipConfirmation       bool    `mapstructure:"-"`                        // SkipConfirmation broadcasts interactive transactions without confirmation (not persisted).
 }
 
 // GetAuthzGranterAddr returns the AuthzGranterAddr field as AccAddress.
@@ -112,6 +113,11 @@ func (c *TxConfig) GetSimulateAndExecute() bool {
 	return c.SimulateAndExecute
 }
 
+// GetSkipConfirmation returns the SkipConfirmation field.
+func (c *TxConfig) GetSkipConfirmation() bool {
+	return c.SkipConfirmation
+}
+
 // Validate ensures the TxConfig has valid fields.
 func (c *TxConfig) Validate() error {
 	// Validate AuthzGranterAddr if it's not empty.
@@ -169,6 +175,7 @@ func (c *TxConfig) SetForFlags(f *pflag.FlagSet) {
 	f.Float64Var(&c.GasAdjustment, "tx.gas-adjustment", c.GasAdjustment, "adjustment factor for gas estimation")
 	f.StringVar(&c.GasPrices, "tx.gas-prices", c.GasPrices, "price of gas for the transaction")
 	f.BoolVar(&c.SimulateAndExecute, "tx.simulate-and-execute", c.SimulateAndExecute, "simulate the transaction before execution")
+	f.BoolVarP(&c.SkipConfirmation, "yes", "y", c.SkipConfirmation, "broadcast transactions without asking for confirmation")
 	f.UintVar(&c.QueryRetryAttempts, "tx.query-retry-attempts", c.QueryRetryAttempts, "number of times to retry querying a transaction")
 	f.StringVar(&c.QueryRetryDelay, "tx.query-retry-delay", c.QueryRetryDelay, "delay between transaction query retries")
 }
@@ -187,5 +194,6 @@ func DefaultTxConfig() *TxConfig {
 		QueryRetryAttempts:     30,
 		QueryRetryDelay:        "1s",
 		SimulateAndExecute:     true,
+		SkipConfirmation:       false,
 	}
 }
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SignatureResult represents an ADR-36 signature of arbitrary data along with the key that produced it.
+type SignatureResult struct {
+	Addr      cosmossdk.AccAddress // Addr is the address of the signer.
+	PubKey    types.PubKey         // PubKey is the public key of the signer.
+	Signature []byte               // Signature is the signature of the ADR-36 sign document.
+}
+
+// adr36SignDoc is the amino JSON sign document of ADR-36, with its fields in sorted order.
+type adr36SignDoc struct {
+	AccountNumber string     `json:"account_number"`
+	ChainID       string     `json:"chain_id"`
+	Fee           adr36Fee   `json:"fee"`
+	Memo          string     `json:"memo"`
+	Msgs          []adr36Msg `json:"msgs"`
+	Sequence      string     `json:"sequence"`
+}
+
+// adr36Fee is the empty fee of an ADR-36 sign document.
+type adr36Fee struct {
+	Amount []struct{} `json:"amount"`
+	Gas    string     `json:"gas"`
+}
+
+// adr36Msg is the MsgSignData message of an ADR-36 sign document.
+type adr36Msg struct {
+	Type  string        `json:"type"`
+	Value adr36MsgValue `json:"value"`
+}
+
+// adr36MsgValue holds the signed data and the signer of a MsgSignData message.
+type adr36MsgValue struct {
+	Data   []byte `json:"data"`
+	Signer string `json:"signer"`
+}
+
+// adr36SignBytes returns the bytes of the ADR-36 sign document for the data signed by the address, which
+// wraps the data in a MsgSignData message with an empty chain ID, a zero account number and sequence,
+// and no fee, as produced by Keplr and CosmJS.
+func adr36SignBytes(addr cosmossdk.AccAddress, data []byte) ([]byte, error) {
+	// Encode nil data as an empty string rather than null, like wallets do for empty data.
+	if data == nil {
+		data = []byte{}
+	}
+
+	doc := adr36SignDoc{
+		AccountNumber: "0",
+		ChainID:       "",
+		Fee:           adr36Fee{Amount: []struct{}{}, Gas: "0"},
+		Memo:          "",
+		Msgs: []adr36Msg{
+			{
+				Type:  "sign/MsgSignData",
+				Value: adr36MsgValue{Data: data, Signer: addr.String()},
+			},
+		},
+		Sequence: "0",
+	}
+
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sign doc: %w", err)
+	}
+
+	return buf, nil
+}
+
+// SignArbitrary signs arbitrary data off-chain as defined by ADR-36 with the key from the keyring identified
+// by the given name, so that the signature can be verified by other Cosmos tooling, for example to prove the
+// ownership of an operator key to an external service.
+func (c *Client) SignArbitrary(name string, data []byte) (*SignatureResult, error) {
+	// Use the default transaction key name if none is provided.
+	if name == "" {
+		name = c.txFromName
+	}
+
+	addr, err := c.KeyAddr(name)
+	if err != nil {
+		return nil, err
+	}
+	if addr == nil {
+		return nil, newErrNotFound(fmt.Errorf("key %s does not exist", name))
+	}
+
+	buf, err := adr36SignBytes(addr, data)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, pubKey, err := c.Sign(name, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignatureResult{
+		Addr:      addr,
+		PubKey:    pubKey,
+		Signature: signature,
+	}, nil
+}
+
+// VerifyArbitrary verifies an ADR-36 signature of arbitrary data by the given address, such as one returned
+// by SignArbitrary or produced by Keplr or CosmJS. The public key must belong to the address.
+// It returns ErrInvalidSignature if the signature does not match.
+func (c *Client) VerifyArbitrary(addr cosmossdk.AccAddress, data, sig []byte, pubKey types.PubKey) error {
+	if pubKey == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidSignature)
+	}
+	if !addr.Equals(cosmossdk.AccAddress(pubKey.Address())) {
+		return fmt.Errorf("%w: public key does not belong to %s", ErrInvalidSignature, addr)
+	}
+
+	buf, err := adr36SignBytes(addr, data)
+	if err != nil {
+		return err
+	}
+
+	if !pubKey.VerifySignature(buf, sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
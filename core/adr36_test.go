@@ -0,0 +1,182 @@
+package core_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/qubetics/qubetics-blockchain/v2/crypto/ethsecp256k1"
+
+	"github.com/qubetics/qubetics-go-sdk/core"
+	"github.com/qubetics/qubetics-go-sdk/coretest"
+)
+
+// keplrSignDoc returns the ADR-36 sign document as serialized by Keplr's makeADR36AminoSignDoc and
+// CosmJS's serializeSignDoc, written out independently of the implementation under test.
+func keplrSignDoc(signer string, data []byte) []byte {
+	return []byte(fmt.Sprintf(
+		`{"account_number":"0","chain_id":"","fee":{"amount":[],"gas":"0"},"memo":"",`+
+			`"msgs":[{"type":"sign/MsgSignData","value":{"data":"%s","signer":"%s"}}],"sequence":"0"}`,
+		base64.StdEncoding.EncodeToString(data), signer,
+	))
+}
+
+func TestVerifyArbitraryKeplrSignDoc(t *testing.T) {
+	ethKey, err := ethsecp256k1.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  types.PrivKey
+		data []byte
+	}{
+		{name: "secp256k1", key: secp256k1.GenPrivKey(), data: []byte("hello")},
+		{name: "secp256k1 binary data", key: secp256k1.GenPrivKey(), data: []byte{0x00, 0xff, 0x10}},
+		{name: "eth_secp256k1", key: ethKey, data: []byte("hello")},
+		{name: "eth_secp256k1 empty data", key: ethKey, data: []byte{}},
+	}
+
+	c := core.NewClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := cosmossdk.AccAddress(tt.key.PubKey().Address())
+
+			// Sign the document as the wallet would, hashing with SHA-256 or Keccak-256 by key type.
+			sig, err := tt.key.Sign(keplrSignDoc(addr.String(), tt.data))
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			if err := c.VerifyArbitrary(addr, tt.data, sig, tt.key.PubKey()); err != nil {
+				t.Errorf("VerifyArbitrary() error = %v", err)
+			}
+			if err := c.VerifyArbitrary(addr, []byte("tampered"), sig, tt.key.PubKey()); !errors.Is(err, core.ErrInvalidSignature) {
+				t.Errorf("VerifyArbitrary() of different data error = %v, want %v", err, core.ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestVerifyArbitraryFixedVector(t *testing.T) {
+	// The signatures were produced over the Keplr sign document by an independent secp256k1 implementation
+	// (decred's dcrec/secp256k1 with RFC 6979 nonces), for the signer address under each Bech32 prefix.
+	const (
+		pubKey = "A8CCo3t6nJic3Al0NUrE/84klx/z7GfO0E6YY+Bpm3Cm"
+		data   = "I control this node operator key"
+	)
+
+	vectors := map[string]struct {
+		signer    string
+		signature string
+	}{
+		"cosmos": {
+			signer:    "cosmos16q6n5nh28f6w9qza7fkv8gvvr6t92cjlsjl3fz",
+			signature: "YrGScDsLLY/YmWDV3OqUv5TRDcpFyl+UIR6y+DJezLFVZsk6zJdspkL1SWsYcdWJJ6jyEjIKQsU2qOU/vD2M0A==",
+		},
+		"qubetics": {
+			signer:    "qubetics16q6n5nh28f6w9qza7fkv8gvvr6t92cjlj6rt94",
+			signature: "V+HFJOnu70Uy67/cpwx0K+rcA6wZeYPD6kMvz0AGN78SjneIX2Lc2vZxnIEzaOpO4taURqVX1P5lB5E8jsGNsQ==",
+		},
+	}
+
+	prefix := cosmossdk.GetConfig().GetBech32AccountAddrPrefix()
+	vector, ok := vectors[prefix]
+	if !ok {
+		t.Fatalf("no vector for bech32 prefix %s", prefix)
+	}
+
+	buf, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil {
+		t.Fatalf("failed to decode pubkey: %v", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(vector.signature)
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+
+	addr, err := cosmossdk.AccAddressFromBech32(vector.signer)
+	if err != nil {
+		t.Fatalf("AccAddressFromBech32() error = %v", err)
+	}
+
+	key := &secp256k1.PubKey{Key: buf}
+	if !addr.Equals(cosmossdk.AccAddress(key.Address())) {
+		t.Fatalf("signer %s does not match pubkey address %s", addr, cosmossdk.AccAddress(key.Address()))
+	}
+
+	c := core.NewClient()
+	if err := c.VerifyArbitrary(addr, []byte(data), sig, key); err != nil {
+		t.Errorf("VerifyArbitrary() error = %v", err)
+	}
+}
+
+func TestSignArbitraryNilData(t *testing.T) {
+	c, _ := coretest.NewOfflineClient(t)
+
+	res, err := c.SignArbitrary("", nil)
+	if err != nil {
+		t.Fatalf("SignArbitrary() error = %v", err)
+	}
+
+	// Nil data is signed like empty data, with "data":"" in the sign document.
+	if !res.PubKey.VerifySignature(keplrSignDoc(res.Addr.String(), nil), res.Signature) {
+		t.Errorf("signature does not match the Keplr sign document with empty data")
+	}
+	if err := c.VerifyArbitrary(res.Addr, []byte{}, res.Signature, res.PubKey); err != nil {
+		t.Errorf("VerifyArbitrary() of empty data error = %v", err)
+	}
+}
+
+func TestSignArbitraryRoundTrip(t *testing.T) {
+	c, _ := coretest.NewOfflineClient(t)
+	data := []byte("proof of operator key")
+
+	res, err := c.SignArbitrary("", data)
+	if err != nil {
+		t.Fatalf("SignArbitrary() error = %v", err)
+	}
+	if !res.Addr.Equals(coretest.Addr(t, c)) {
+		t.Errorf("SignArbitrary() addr = %s, want %s", res.Addr, coretest.Addr(t, c))
+	}
+
+	// The signature covers the sign document a wallet would build for the same data.
+	if !res.PubKey.VerifySignature(keplrSignDoc(res.Addr.String(), data), res.Signature) {
+		t.Errorf("signature does not match the Keplr sign document")
+	}
+	if err := c.VerifyArbitrary(res.Addr, data, res.Signature, res.PubKey); err != nil {
+		t.Errorf("VerifyArbitrary() error = %v", err)
+	}
+
+	other := secp256k1.GenPrivKey().PubKey()
+	tests := []struct {
+		name   string
+		addr   cosmossdk.AccAddress
+		data   []byte
+		pubKey types.PubKey
+	}{
+		{name: "different data", addr: res.Addr, data: []byte("other"), pubKey: res.PubKey},
+		{name: "different signer", addr: cosmossdk.AccAddress(other.Address()), data: data, pubKey: other},
+		{name: "key of another addr", addr: res.Addr, data: data, pubKey: other},
+		{name: "missing key", addr: res.Addr, data: data, pubKey: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.VerifyArbitrary(tt.addr, tt.data, res.Signature, tt.pubKey)
+			if !errors.Is(err, core.ErrInvalidSignature) {
+				t.Errorf("VerifyArbitrary() error = %v, want %v", err, core.ErrInvalidSignature)
+			}
+		})
+	}
+
+	if _, err := c.SignArbitrary("missing", data); err == nil {
+		t.Errorf("SignArbitrary() with a missing key succeeded")
+	}
+}
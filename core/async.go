@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// broadcastTxAsync broadcasts a signed transaction asynchronously and returns the broadcast result.
+func (c *Client) broadcastTxAsync(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+	// Serialize the broadcasts of the account if its sequence is tracked locally, so that each one takes
+	// the next sequence.
+	if c.txSequenceTracking {
+		_, addr, err := c.txSigner()
+		if err != nil {
+			return nil, err
+		}
+
+		mu := c.sequenceLock(addr)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	// Resolve the transaction parameters for this call.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.sign = true
+
+	// Build the unsigned transaction for the messages.
+	txb, key, acc, err := c.buildTx(ctx, params, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the transaction.
+	if err := c.signTx(txb, key, acc); err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	// Encode the signed transaction into bytes.
+	buf, err := c.txConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx: %w", err)
+	}
+
+	// Broadcast the transaction asynchronously, failing over to the next RPC server on network errors.
+	var res *core.ResultBroadcastTx
+	err = c.withRPC(ctx, func(backend Backend) (err error) {
+		res, err = backend.BroadcastTxAsync(ctx, buf)
+		if err != nil {
+			return fmt.Errorf("failed to async broadcast tx: %w", err)
+		}
+
+		return nil
+	})
+
+	// Update the locally tracked sequence if enabled.
+	if c.txSequenceTracking {
+		err = c.trackSequence(acc, res, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BroadcastTxAsync signs a transaction like BroadcastTxSync, wrapping the messages in an authz exec message
+// if configured, and broadcasts it without waiting for CheckTx, with the same retry logic. Since the result is
+// returned before CheckTx, it only holds the hash of the transaction, which can be resolved later with Tx.
+//
+// Since the committed account sequence does not include transactions still in the mempool, enable
+// Client.WithTxSequenceTracking to broadcast transactions in a loop, so that each one takes the next
+// locally tracked sequence. A transaction rejected by CheckTx then leaves a gap in the sequences that makes
+// the following ones fail; call ResetTxSequences to resume from the committed sequence when a broadcast
+// transaction is never found.
+func (c *Client) BroadcastTxAsync(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+	var err error
+	var resp *core.ResultBroadcastTx
+
+	// Define a function to perform the transaction broadcast.
+	retryFunc := func() error {
+		// Attempt to broadcast the transaction.
+		resp, err = c.broadcastTxAsync(ctx, msgs...)
+		if err != nil {
+			// Return nil if the error is related to a mempool cache issue.
+			if IsTxInMempoolCacheError(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	}
+
+	if err := c.retryBroadcast(retryFunc); err != nil {
+		return nil, fmt.Errorf("tx async broadcast failed after retries: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/qubetics/qubetics-go-sdk/coretest"
+	"github.com/qubetics/qubetics-go-sdk/types"
+)
+
+func TestBroadcastTxAsyncHash(t *testing.T) {
+	c, chain := coretest.NewOfflineClient(t)
+	c = c.Clone().WithTxSequenceTracking(true)
+
+	addr := coretest.Addr(t, c)
+	msg := bank.NewMsgSend(addr, addr, cosmossdk.NewCoins(cosmossdk.NewInt64Coin("tics", 1)))
+
+	encoder := tx.NewTxConfig(types.NewProtoCodec(), tx.DefaultSignModes).TxEncoder()
+
+	for i := 0; i < 2; i++ {
+		res, err := c.BroadcastTxAsync(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("BroadcastTxAsync() error = %v", err)
+		}
+
+		broadcasts := chain.Broadcasts()
+		if len(broadcasts) != i+1 {
+			t.Fatalf("got %d broadcasts, want %d", len(broadcasts), i+1)
+		}
+
+		buf, err := encoder(broadcasts[i].Tx)
+		if err != nil {
+			t.Fatalf("failed to encode tx: %v", err)
+		}
+
+		if want := cmttypes.Tx(buf).Hash(); !bytes.Equal(res.Hash, want) {
+			t.Errorf("BroadcastTxAsync() hash = %X, want %X", res.Hash, want)
+		}
+	}
+
+	// Each broadcast takes the next locally tracked sequence.
+	if got := chain.Account(addr).GetSequence(); got != 2 {
+		t.Errorf("account sequence = %d, want 2", got)
+	}
+}
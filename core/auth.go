@@ -23,7 +23,7 @@ func (c *Client) Account(ctx context.Context, accAddr cosmossdk.AccAddress) (res
 	)
 
 	// Perform the gRPC query to fetch the account details.
-	if err := c.QueryGRPC(ctx, methodQueryAccount, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryAccount, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -44,7 +44,7 @@ func (c *Client) Accounts(ctx context.Context, pageReq *query.PageRequest) (res
 	)
 
 	// Perform the gRPC query to fetch paginated account details.
-	if err := c.QueryGRPC(ctx, methodQueryAccounts, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryAccounts, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
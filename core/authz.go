@@ -28,7 +28,7 @@ func (c *Client) AuthzGranteeGrants(ctx context.Context, grantee types.AccAddres
 	)
 
 	// Perform the gRPC query to fetch the grants assigned to the specified grantee.
-	if err := c.QueryGRPC(ctx, methodQueryAuthzGranteeGrants, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryAuthzGranteeGrants, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -47,7 +47,7 @@ func (c *Client) AuthzGranterGrants(ctx context.Context, granter types.AccAddres
 	)
 
 	// Perform the gRPC query to fetch the grants issued by the specified granter.
-	if err := c.QueryGRPC(ctx, methodQueryAuthzGranterGrants, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryAuthzGranterGrants, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -68,7 +68,7 @@ func (c *Client) AuthzGrants(ctx context.Context, granter, grantee types.AccAddr
 	)
 
 	// Perform the gRPC query to fetch the grants for the specified granter and grantee.
-	if err := c.QueryGRPC(ctx, methodQueryAuthzGrants, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryAuthzGrants, req, &resp); err != nil {
 		if strings.Contains(err.Error(), authz.ErrNoAuthorizationFound.Error()) {
 			return nil, nil, nil
 		}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/rpc/client"
+	"github.com/cometbft/cometbft/rpc/client/http"
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Backend is the set of RPC methods the client uses to query the chain and broadcast transactions.
+// The CometBFT HTTP client of the configured RPC addresses is used by default; another implementation,
+// such as a mock in unit tests, can be set with WithBackend.
+type Backend interface {
+	ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes, opts client.ABCIQueryOptions) (*core.ResultABCIQuery, error)
+	BroadcastTxAsync(ctx context.Context, tx types.Tx) (*core.ResultBroadcastTx, error)
+	BroadcastTxSync(ctx context.Context, tx types.Tx) (*core.ResultBroadcastTx, error)
+	Status(ctx context.Context) (*core.ResultStatus, error)
+	Tx(ctx context.Context, hash []byte, prove bool) (*core.ResultTx, error)
+}
+
+var _ Backend = (*http.HTTP)(nil)
+
+// rpcBackend returns the backend set with WithBackend, or otherwise the RPC client of the active address.
+// Returns ErrClientClosed after Close.
+func (c *Client) rpcBackend() (Backend, error) {
+	if c.backend == nil {
+		v, err := c.HTTP()
+		if err != nil {
+			return nil, err
+		}
+
+		return v, nil
+	}
+
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	// Mark the client as in use, ending its configuration phase.
+	c.used.Store(true)
+
+	return c.backend, nil
+}
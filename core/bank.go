@@ -12,6 +12,10 @@ const (
 	// gRPC methods for querying bank balances
 	methodQueryBalance  = "/cosmos.bank.v1beta1.Query/Balance"     // Retrieve the balance of a specific account
 	methodQueryBalances = "/cosmos.bank.v1beta1.Query/AllBalances" // Retrieve all balances for a given account
+
+	// gRPC methods for querying bank supply
+	methodQuerySupplyOf    = "/cosmos.bank.v1beta1.Query/SupplyOf"    // Retrieve the total supply of a specific denomination
+	methodQueryTotalSupply = "/cosmos.bank.v1beta1.Query/TotalSupply" // Retrieve the total supply of all denominations
 )
 
 // Balance retrieves the balance of a specific account and denomination.
@@ -26,7 +30,7 @@ func (c *Client) Balance(ctx context.Context, accAddr cosmossdk.AccAddress, deno
 	)
 
 	// Perform the gRPC query to fetch the account balance.
-	if err := c.QueryGRPC(ctx, methodQueryBalance, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryBalance, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -45,9 +49,45 @@ func (c *Client) Balances(ctx context.Context, accAddr cosmossdk.AccAddress, pag
 	)
 
 	// Perform the gRPC query to fetch the account balances.
-	if err := c.QueryGRPC(ctx, methodQueryBalances, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryBalances, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
 	return resp.Balances, resp.Pagination, nil
 }
+
+// SupplyOf retrieves the total supply of a specific denomination.
+// Returns the supply details and any error encountered.
+func (c *Client) SupplyOf(ctx context.Context, denom string) (res *cosmossdk.Coin, err error) {
+	var (
+		resp bank.QuerySupplyOfResponse
+		req  = &bank.QuerySupplyOfRequest{
+			Denom: denom,
+		}
+	)
+
+	// Perform the gRPC query to fetch the supply of the denomination.
+	if err := c.QueryGRPCDirect(ctx, methodQuerySupplyOf, req, &resp); err != nil {
+		return nil, IsCodeNotFound(err)
+	}
+
+	return &resp.Amount, nil
+}
+
+// TotalSupply retrieves the total supply of all denominations with pagination.
+// Returns the supply, pagination details, and any error encountered.
+func (c *Client) TotalSupply(ctx context.Context, pageReq *query.PageRequest) (res cosmossdk.Coins, pageRes *query.PageResponse, err error) {
+	var (
+		resp bank.QueryTotalSupplyResponse
+		req  = &bank.QueryTotalSupplyRequest{
+			Pagination: pageReq,
+		}
+	)
+
+	// Perform the gRPC query to fetch the total supply.
+	if err := c.QueryGRPCDirect(ctx, methodQueryTotalSupply, req, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Supply, resp.Pagination, nil
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BatchError holds the errors of the transactions of a batch broadcast that failed.
+type BatchError struct {
+	Errs []error // Errs holds the error of each transaction by index, or nil if it was accepted.
+}
+
+// Error returns the errors of the failed transactions along with their indexes.
+func (e *BatchError) Error() string {
+	var items []string
+	for i, err := range e.Errs {
+		if err != nil {
+			items = append(items, fmt.Sprintf("tx %d: %s", i, err))
+		}
+	}
+
+	return fmt.Sprintf("%d of %d txs failed: %s", len(items), len(e.Errs), strings.Join(items, "; "))
+}
+
+// Unwrap returns the errors of the failed transactions.
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// BroadcastBatch signs and broadcasts a transaction for each group of messages in rapid succession, in sync mode.
+// The account is queried once, and sequence numbers are assigned locally in order. If sequence tracking is enabled,
+// the batch starts from the locally tracked sequence and updates it, so that other broadcasts continue after the
+// batch. A sequence is only consumed when its transaction is accepted by the mempool, so a rejected transaction
+// does not leave a gap that would cause the following ones to fail. All transactions are signed by the client's key;
+// use Clone with another sender name to broadcast a batch for a different signer.
+//
+// The returned results are indexed like txs, and a result is nil if its transaction could not be broadcast.
+// If any transaction fails, a *BatchError holding the error of each transaction is returned along with the results.
+func (c *Client) BroadcastBatch(ctx context.Context, txs [][]cosmossdk.Msg) ([]*core.ResultBroadcastTx, error) {
+	// Ensure the client is fully set up before signing.
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	key, addr, err := c.txSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize the batch with the other broadcasts of the account if its sequence is tracked locally.
+	if c.txSequenceTracking {
+		mu := c.sequenceLock(addr)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	// Resolve the transaction parameters once for the whole batch.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.sign = true
+
+	// Retrieve the sender's account information once for the whole batch.
+	acc, err := c.Account(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account: %w", err)
+	}
+	if acc == nil {
+		return nil, newErrNotFound(fmt.Errorf("acconut %s does not exist", addr))
+	}
+
+	// Start from the locally tracked sequence if enabled, which accounts for transactions still in the mempool.
+	if c.txSequenceTracking {
+		if err := c.applySequence(acc); err != nil {
+			return nil, fmt.Errorf("failed to set account sequence: %w", err)
+		}
+	}
+
+	// Get the backend for broadcasting the transactions. The batch is not failed over to another RPC server,
+	// since the locally assigned sequences rely on the mempool of a single node.
+	backend, err := c.rpcBackend()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client: %w", err)
+	}
+
+	results := make([]*core.ResultBroadcastTx, len(txs))
+	batchErr := &BatchError{Errs: make([]error, len(txs))}
+	failed := false
+
+	// broadcast prepares, signs and broadcasts a single transaction with the current account sequence.
+	broadcast := func(msgs []cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+		msgs, err := c.txMsgs(addr, msgs)
+		if err != nil {
+			return nil, err
+		}
+
+		// Simulation runs against the mempool state, so the local sequence is valid for it.
+		txb, err := c.prepareTx(ctx, params, key, acc, msgs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare tx: %w", err)
+		}
+		if err := c.signTx(txb, key, acc); err != nil {
+			return nil, fmt.Errorf("failed to sign tx: %w", err)
+		}
+
+		buf, err := c.txConfig.TxEncoder()(txb.GetTx())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tx: %w", err)
+		}
+
+		res, err := backend.BroadcastTxSync(ctx, buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync broadcast tx: %w", err)
+		}
+
+		return res, nil
+	}
+
+	sequence := acc.GetSequence()
+	for i, msgs := range txs {
+		// Stop broadcasting once the context is done, failing the remaining transactions.
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(txs); j++ {
+				batchErr.Errs[j] = err
+			}
+
+			failed = true
+			break
+		}
+
+		if err := acc.SetSequence(sequence); err != nil {
+			return nil, fmt.Errorf("failed to set account sequence: %w", err)
+		}
+
+		res, err := broadcast(msgs)
+		results[i] = res
+
+		// Update the locally tracked sequence if enabled, so that later broadcasts continue after the batch.
+		if c.txSequenceTracking {
+			err = c.trackSequence(acc, res, err)
+		}
+		if err != nil {
+			batchErr.Errs[i] = err
+			failed = true
+			continue
+		}
+
+		// Ensure the transaction was accepted by the mempool before consuming its sequence.
+		if res.Code != abci.CodeTypeOK {
+			err := fmt.Errorf("code=%d, codespace=%s, log=%s", res.Code, res.Codespace, res.Log)
+			batchErr.Errs[i] = fmt.Errorf("tx sync broadcast failed: %w", err)
+			failed = true
+			continue
+		}
+
+		sequence++
+	}
+
+	if failed {
+		return results, batchErr
+	}
+
+	return results, nil
+}
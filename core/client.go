@@ -1,29 +1,49 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/cometbft/cometbft/rpc/client/http"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"google.golang.org/grpc"
 
 	"github.com/qubetics/qubetics-go-sdk/config"
 	"github.com/qubetics/qubetics-go-sdk/types"
 )
 
+// ErrClientClosed is returned by operations on a Client after Close has been called.
+var ErrClientClosed = errors.New("client is closed")
+
 // Client contains all necessary components for transaction handling, query management, and configuration settings.
+//
+// A Client has a configuration phase followed by a use phase. The With... setters are not safe for
+// concurrent use and must only be called while configuring the client; once the client has made its
+// first RPC request, they panic, since they would race with in-flight queries and broadcasts reading
+// the same fields. After configuration, the client is safe for concurrent use. To use different settings
+// for some calls, derive a client with Clone or pass per-call overrides through the context (see WithGasLimit).
 type Client struct {
+	backend                  Backend              // Backend used instead of the RPC client, if set
+	grpcAddr                 string               // gRPC server address for queries, if any
 	keyring                  keyring.Keyring      // Keyring for managing private keys and signatures
 	protoCodec               codec.Codec          // Used for marshaling and unmarshaling protobuf data
 	queryHeight              int64                // Query height for blockchain data
 	queryProve               bool                 // Flag indicating whether to prove queries
 	queryRetryAttempts       uint                 // Number of retry attempts for queries
 	queryRetryDelay          time.Duration        // Delay between query retries
-	rpcAddr                  string               // RPC server address
+	rpcAddrs                 []string             // RPC server addresses, in order of preference
 	rpcChainID               string               // The chain ID used to identify the blockchain network
 	rpcTimeout               time.Duration        // RPC timeout duration
 	txAuthzGranterAddr       cosmossdk.AccAddress // Address that grants transaction authorization
@@ -31,16 +51,35 @@ type Client struct {
 	txBroadcastRetryDelay    time.Duration        // Delay between transaction broadcast retries
 	txConfig                 client.TxConfig      // Configuration related to transactions (e.g., signing modes)
 	txFeeGranterAddr         cosmossdk.AccAddress // Address that grants transaction fees
+	txFeePayer               cosmossdk.AccAddress // Address that pays transaction fees directly
 	txFees                   cosmossdk.Coins      // Fees for transactions
 	txFromName               string               // Sender name for transactions
 	txGasAdjustment          float64              // Adjustment factor for gas estimation
+	txGasHints               map[string]float64   // Adjustment factors for gas estimation keyed by message type URL
 	txGasPrices              cosmossdk.DecCoins   // Gas price settings for transactions
 	txGas                    uint64               // Gas limit for transactions
 	txMemo                   string               // Memo attached to transactions
+	txMemoTemplate           *template.Template   // Template rendering the memo of each transaction
+	txMemoTemplateErr        error                // Error from parsing the memo template
 	txQueryRetryAttempts     uint                 // Number of retry attempts for transaction queries
 	txQueryRetryDelay        time.Duration        // Delay between transaction query retries
+	txSequenceTracking       bool                 // Flag for tracking account sequences locally for sync broadcasts
+	txSignMode               txsigning.SignMode   // Sign mode for transactions
+	txSkipConfirmation       bool                 // Flag for broadcasting interactive transactions without confirmation
 	txSimulateAndExecute     bool                 // Flag for simulating and executing transactions
 	txTimeoutHeight          uint64               // Transaction timeout height
+	txWaitMode               TxWaitMode           // How to wait for transactions to be included in a block
+
+	closed    bool                   // Whether Close has been called
+	grpcConn  *grpc.ClientConn       // Cached gRPC connection, created on first use
+	rpcIndex  int                    // Index of the active RPC address
+	rpcClient *http.HTTP             // Cached RPC client, created on first use
+	rpcHTTP   *nethttp.Client        // HTTP client used by the cached RPC client
+	rpcMu     sync.Mutex             // Guards closed, the active RPC address and the cached RPC and gRPC clients
+	seqLocks  map[string]*sync.Mutex // Serializes the broadcasts of each account with a tracked sequence
+	seqMu     sync.Mutex             // Guards seqLocks and seqNext
+	seqNext   map[string]uint64      // Next sequence of each account, tracked locally
+	used      atomic.Bool            // Whether the client has been used for RPC requests
 }
 
 // NewClient initializes a new Client instance.
@@ -57,154 +96,475 @@ func NewClient() *Client {
 	return c
 }
 
+// ensureConfigurable panics if the client is already in use, as setters would race with requests in flight.
+func (c *Client) ensureConfigurable() {
+	if c.used.Load() {
+		panic("core: Client setters must not be called after the client is in use; use Clone to derive a customized client")
+	}
+}
+
+// Clone returns a copy of the client that can be customized with the With... setters without affecting
+// the original, for example to use a different sender or gas limit in another goroutine.
+// The keyring, protobuf codec and transaction configuration are shared, since they are safe for concurrent use;
+// all other settings are copied, including the fee and gas price coins and the granter addresses.
+// The clone creates its own RPC client on first use and is not closed by closing the original.
+func (c *Client) Clone() *Client {
+	c.rpcMu.Lock()
+	rpcIndex := c.rpcIndex
+	c.rpcMu.Unlock()
+
+	return &Client{
+		backend:                  c.backend,
+		grpcAddr:                 c.grpcAddr,
+		keyring:                  c.keyring,
+		protoCodec:               c.protoCodec,
+		queryHeight:              c.queryHeight,
+		queryProve:               c.queryProve,
+		queryRetryAttempts:       c.queryRetryAttempts,
+		queryRetryDelay:          c.queryRetryDelay,
+		rpcAddrs:                 append([]string(nil), c.rpcAddrs...),
+		rpcChainID:               c.rpcChainID,
+		rpcTimeout:               c.rpcTimeout,
+		txAuthzGranterAddr:       append(cosmossdk.AccAddress(nil), c.txAuthzGranterAddr...),
+		txBroadcastRetryAttempts: c.txBroadcastRetryAttempts,
+		txBroadcastRetryDelay:    c.txBroadcastRetryDelay,
+		txConfig:                 c.txConfig,
+		txFeeGranterAddr:         append(cosmossdk.AccAddress(nil), c.txFeeGranterAddr...),
+		txFeePayer:               append(cosmossdk.AccAddress(nil), c.txFeePayer...),
+		txFees:                   append(cosmossdk.Coins(nil), c.txFees...),
+		txFromName:               c.txFromName,
+		txGasAdjustment:          c.txGasAdjustment,
+		txGasHints:               copyGasHints(c.txGasHints),
+		txGasPrices:              append(cosmossdk.DecCoins(nil), c.txGasPrices...),
+		txGas:                    c.txGas,
+		txMemo:                   c.txMemo,
+		txMemoTemplate:           c.txMemoTemplate,
+		txMemoTemplateErr:        c.txMemoTemplateErr,
+		txQueryRetryAttempts:     c.txQueryRetryAttempts,
+		txQueryRetryDelay:        c.txQueryRetryDelay,
+		txSequenceTracking:       c.txSequenceTracking,
+		txSignMode:               c.txSignMode,
+		txSkipConfirmation:       c.txSkipConfirmation,
+		txSimulateAndExecute:     c.txSimulateAndExecute,
+		txTimeoutHeight:          c.txTimeoutHeight,
+		txWaitMode:               c.txWaitMode,
+
+		rpcIndex: rpcIndex,
+	}
+}
+
 // ProtoCodec returns the protobuf codec used for marshaling and unmarshaling data.
 func (c *Client) ProtoCodec() codec.Codec {
 	return c.protoCodec
 }
 
+// WithBackend sets the backend used to query the chain and broadcast transactions instead of the RPC client of
+// the configured RPC addresses, and returns the updated Client. It is meant for unit tests with a mock backend;
+// requests are not failed over, and waiting in TxWaitModeSubscribe still needs an RPC address.
+func (c *Client) WithBackend(backend Backend) *Client {
+	c.ensureConfigurable()
+	c.backend = backend
+	return c
+}
+
+// WithGRPCAddr sets the gRPC server address used by QueryGRPCDirect and returns the updated Client.
+// Queries go through ABCI over the RPC server if it is empty.
+func (c *Client) WithGRPCAddr(addr string) *Client {
+	c.ensureConfigurable()
+	c.grpcAddr = addr
+	return c
+}
+
 // WithKeyring assigns the keyring to the Client and returns the updated Client.
 func (c *Client) WithKeyring(keyring keyring.Keyring) *Client {
+	c.ensureConfigurable()
 	c.keyring = keyring
 	return c
 }
 
 // WithProtoCodec sets the protobuf codec and returns the updated Client.
 func (c *Client) WithProtoCodec(protoCodec codec.ProtoCodecMarshaler) *Client {
+	c.ensureConfigurable()
 	c.protoCodec = protoCodec
 	return c
 }
 
 // WithQueryProve sets the prove flag for queries and returns the updated Client.
 func (c *Client) WithQueryProve(prove bool) *Client {
+	c.ensureConfigurable()
 	c.queryProve = prove
 	return c
 }
 
 // WithQueryRetryAttempts sets the number of retry attempts for queries and returns the updated Client.
 func (c *Client) WithQueryRetryAttempts(attempts uint) *Client {
+	c.ensureConfigurable()
 	c.queryRetryAttempts = attempts
 	return c
 }
 
 // WithQueryRetryDelay sets the retry delay duration for queries and returns the updated Client.
 func (c *Client) WithQueryRetryDelay(delay time.Duration) *Client {
+	c.ensureConfigurable()
 	c.queryRetryDelay = delay
 	return c
 }
 
 // WithRPCAddr sets the RPC server address and returns the updated Client.
 func (c *Client) WithRPCAddr(rpcAddr string) *Client {
-	c.rpcAddr = rpcAddr
+	return c.WithRPCAddrs([]string{rpcAddr})
+}
+
+// WithRPCAddrs sets the RPC server addresses and returns the updated Client.
+// Requests use the first address until it fails with a network-level error, after which the client fails over
+// to the next address and sticks to it, wrapping around the list. See RPCAddr for the address in use.
+func (c *Client) WithRPCAddrs(rpcAddrs []string) *Client {
+	c.ensureConfigurable()
+	c.rpcAddrs = nil
+	for _, addr := range rpcAddrs {
+		if addr != "" {
+			c.rpcAddrs = append(c.rpcAddrs, addr)
+		}
+	}
+
+	c.rpcIndex = 0
+	c.resetHTTP()
 	return c
 }
 
 // WithRPCChainID sets the blockchain chain ID and returns the updated Client.
 func (c *Client) WithRPCChainID(chainID string) *Client {
+	c.ensureConfigurable()
 	c.rpcChainID = chainID
 	return c
 }
 
 // WithRPCTimeout sets the RPC timeout duration and returns the updated Client.
 func (c *Client) WithRPCTimeout(timeout time.Duration) *Client {
+	c.ensureConfigurable()
 	c.rpcTimeout = timeout
+	c.resetHTTP()
 	return c
 }
 
 // WithTxAuthzGranterAddr sets the transaction authorization granter address and returns the updated Client.
 func (c *Client) WithTxAuthzGranterAddr(addr cosmossdk.AccAddress) *Client {
+	c.ensureConfigurable()
 	c.txAuthzGranterAddr = addr
 	return c
 }
 
 // WithTxBroadcastRetryAttempts sets the number of retry attempts for broadcasting transactions and returns the updated Client.
 func (c *Client) WithTxBroadcastRetryAttempts(attempts uint) *Client {
+	c.ensureConfigurable()
 	c.txBroadcastRetryAttempts = attempts
 	return c
 }
 
 // WithTxBroadcastRetryDelay sets the retry delay duration for broadcasting transactions and returns the updated Client.
 func (c *Client) WithTxBroadcastRetryDelay(delay time.Duration) *Client {
+	c.ensureConfigurable()
 	c.txBroadcastRetryDelay = delay
 	return c
 }
 
 // WithTxConfig sets the transaction configuration and returns the updated Client.
 func (c *Client) WithTxConfig(txConfig client.TxConfig) *Client {
+	c.ensureConfigurable()
 	c.txConfig = txConfig
 	return c
 }
 
 // WithTxFeeGranterAddr sets the transaction fee granter address and returns the updated Client.
+// The fees are deducted from the granter's balance through a feegrant allowance, and the granter does not sign.
 func (c *Client) WithTxFeeGranterAddr(addr cosmossdk.AccAddress) *Client {
+	c.ensureConfigurable()
 	c.txFeeGranterAddr = addr
 	return c
 }
 
+// WithTxFeePayer sets the transaction fee payer and returns the updated Client.
+// Unlike a fee granter, the fee payer pays the fees directly from its balance without a feegrant allowance,
+// and therefore becomes a required signer of the transaction. Since the client only signs with the sender's key,
+// a fee payer distinct from the sender is only supported by GenerateUnsignedTx, EstimateFees and SimulateTx, and
+// the broadcast methods fail with ErrFeePayerNotSigner; the exported transaction is signed by both accounts
+// elsewhere and broadcast with BroadcastSignedTx. An empty address makes the first signer pay the fees.
+func (c *Client) WithTxFeePayer(addr cosmossdk.AccAddress) *Client {
+	c.ensureConfigurable()
+	c.txFeePayer = addr
+	return c
+}
+
 // WithTxFees assigns transaction fees and returns the updated Client.
 func (c *Client) WithTxFees(fees cosmossdk.Coins) *Client {
+	c.ensureConfigurable()
 	c.txFees = fees
 	return c
 }
 
 // WithTxFromName sets the "from" name for transactions and returns the updated Client.
 func (c *Client) WithTxFromName(name string) *Client {
+	c.ensureConfigurable()
 	c.txFromName = name
 	return c
 }
 
 // WithTxGasAdjustment sets the gas adjustment factor for transactions and returns the updated Client.
 func (c *Client) WithTxGasAdjustment(adjustment float64) *Client {
+	c.ensureConfigurable()
 	c.txGasAdjustment = adjustment
 	return c
 }
 
+// WithTxGasHints sets the gas adjustment factors used instead of the global gas adjustment for transactions
+// whose messages all have a hint, keyed by message type URL, and returns the updated Client.
+// See RecommendedGasHints for hints suitable for the vpn-module messages.
+func (c *Client) WithTxGasHints(hints map[string]float64) *Client {
+	c.ensureConfigurable()
+	c.txGasHints = copyGasHints(hints)
+	return c
+}
+
 // WithTxGasPrices sets the gas prices for transactions and returns the updated Client.
 func (c *Client) WithTxGasPrices(prices cosmossdk.DecCoins) *Client {
+	c.ensureConfigurable()
 	c.txGasPrices = prices
 	return c
 }
 
 // WithTxGas sets the gas limit for transactions and returns the updated Client.
 func (c *Client) WithTxGas(gas uint64) *Client {
+	c.ensureConfigurable()
 	c.txGas = gas
 	return c
 }
 
 // WithTxMemo sets the memo for transactions and returns the updated Client.
 func (c *Client) WithTxMemo(memo string) *Client {
+	c.ensureConfigurable()
 	c.txMemo = memo
 	return c
 }
 
+// WithTxMemoTemplate sets a text/template that renders the memo of each transaction, taking precedence over
+// the static memo, and returns the updated Client. The template is executed with the data passed through
+// the context with WithMemoData, for example "session:{{.SessionID}}", and fails on missing keys.
+// The template is parsed immediately, and a parse error is reported by Validate. An empty text removes the template.
+func (c *Client) WithTxMemoTemplate(text string) *Client {
+	c.ensureConfigurable()
+	c.txMemoTemplate, c.txMemoTemplateErr = nil, nil
+	if text == "" {
+		return c
+	}
+
+	tmpl, err := template.New("memo").Option("missingkey=error").Parse(text)
+	if err != nil {
+		c.txMemoTemplateErr = fmt.Errorf("failed to parse tx memo template: %w", err)
+		return c
+	}
+
+	c.txMemoTemplate = tmpl
+	return c
+}
+
 // WithTxQueryRetryAttempts sets the number of retry attempts for transaction queries and returns the updated Client.
 func (c *Client) WithTxQueryRetryAttempts(attempts uint) *Client {
+	c.ensureConfigurable()
 	c.txQueryRetryAttempts = attempts
 	return c
 }
 
 // WithTxQueryRetryDelay sets the retry delay duration for transaction queries and returns the updated Client.
 func (c *Client) WithTxQueryRetryDelay(delay time.Duration) *Client {
+	c.ensureConfigurable()
 	c.txQueryRetryDelay = delay
 	return c
 }
 
+// WithTxSequenceTracking sets whether BroadcastTxSync tracks account sequences locally and returns the
+// updated Client. When enabled, the broadcasts of each account are serialized, the sequence is incremented
+// after each transaction accepted by CheckTx, so that transactions can be broadcast in quick succession
+// without reading the same committed sequence, and the tracked sequence is re-synced from the chain on an
+// account sequence mismatch. It is disabled by default.
+func (c *Client) WithTxSequenceTracking(tracking bool) *Client {
+	c.ensureConfigurable()
+	c.txSequenceTracking = tracking
+	return c
+}
+
+// WithTxSignMode sets the sign mode for transactions and returns the updated Client.
+// The default is SIGN_MODE_DIRECT, used when the mode is unspecified; SIGN_MODE_LEGACY_AMINO_JSON is needed for hardware wallets that
+// only support amino JSON signing. The mode must be supported by the transaction config.
+func (c *Client) WithTxSignMode(mode txsigning.SignMode) *Client {
+	c.ensureConfigurable()
+	c.txSignMode = mode
+	return c
+}
+
 // WithTxSimulateAndExecute sets the simulate and execute flag and returns the updated Client.
 func (c *Client) WithTxSimulateAndExecute(simulate bool) *Client {
+	c.ensureConfigurable()
 	c.txSimulateAndExecute = simulate
 	return c
 }
 
+// WithTxSkipConfirmation sets whether BroadcastTxInteractive broadcasts without asking for confirmation,
+// as CLI tools do for their --yes flag, and returns the updated Client. See WithSkipConfirmation.
+func (c *Client) WithTxSkipConfirmation(skip bool) *Client {
+	c.ensureConfigurable()
+	c.txSkipConfirmation = skip
+	return c
+}
+
 // WithTxTimeoutHeight sets the timeout height for transactions and returns the updated Client.
 func (c *Client) WithTxTimeoutHeight(height uint64) *Client {
+	c.ensureConfigurable()
 	c.txTimeoutHeight = height
 	return c
 }
 
-// HTTP creates an HTTP client for the given RPC address and timeout configuration.
-// Returns the HTTP client or an error if initialization fails.
+// WithTxWaitMode sets how BroadcastTxBlock and WaitTx wait for transactions to be included in a block
+// and returns the updated Client. TxWaitModeSubscribe returns as soon as the block is committed instead
+// of polling with a fixed delay.
+func (c *Client) WithTxWaitMode(mode TxWaitMode) *Client {
+	c.ensureConfigurable()
+	c.txWaitMode = mode
+	return c
+}
+
+// missingFields returns the names of the required fields that are not set, among those needed for queries
+// and, if tx is true, for signing and broadcasting transactions.
+func (c *Client) missingFields(tx bool) (fields []string) {
+	if len(c.rpcAddrs) == 0 && c.backend == nil {
+		fields = append(fields, "rpc_addr")
+	}
+	if !tx {
+		return fields
+	}
+
+	if c.rpcChainID == "" {
+		fields = append(fields, "rpc_chain_id")
+	}
+	if c.keyring == nil {
+		fields = append(fields, "keyring")
+	}
+	if c.txConfig == nil {
+		fields = append(fields, "tx_config")
+	}
+
+	return fields
+}
+
+// Validate checks that the fields required to query and broadcast transactions are set,
+// reporting all missing fields at once. It is called before broadcasting, and can be called
+// by callers to catch an incomplete setup upfront.
+func (c *Client) Validate() error {
+	if fields := c.missingFields(true); len(fields) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(fields, ", "))
+	}
+	if c.txMemoTemplateErr != nil {
+		return c.txMemoTemplateErr
+	}
+	if !c.txSignModeSupported() {
+		return fmt.Errorf("unsupported tx sign mode %s", c.signMode())
+	}
+
+	return nil
+}
+
+// signMode returns the sign mode for transactions, defaulting to SIGN_MODE_DIRECT if unspecified.
+func (c *Client) signMode() txsigning.SignMode {
+	if c.txSignMode == txsigning.SignMode_SIGN_MODE_UNSPECIFIED {
+		return txsigning.SignMode_SIGN_MODE_DIRECT
+	}
+
+	return c.txSignMode
+}
+
+// txSignModeSupported reports whether the sign mode is supported by the transaction config.
+func (c *Client) txSignModeSupported() bool {
+	for _, mode := range c.txConfig.SignModeHandler().Modes() {
+		if mode == c.signMode() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HTTP returns the RPC client for the configured RPC address and timeout, creating it on first use
+// so that its connections are reused across requests.
+// Returns ErrClientClosed after Close, or an error if initialization fails.
 func (c *Client) HTTP() (*http.HTTP, error) {
-	timeout := uint(c.rpcTimeout / time.Second)
-	return http.NewWithTimeout(c.rpcAddr, "/websocket", timeout)
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	// Mark the client as in use, ending its configuration phase.
+	c.used.Store(true)
+
+	if c.rpcClient != nil {
+		return c.rpcClient, nil
+	}
+	if fields := c.missingFields(false); len(fields) > 0 {
+		return nil, fmt.Errorf("missing required fields: %s", strings.Join(fields, ", "))
+	}
+
+	// Create the underlying HTTP client for the active address, which supports tcp and unix socket addresses.
+	addr := c.rpcAddrs[c.rpcIndex]
+	client, err := jsonrpcclient.DefaultHTTPClient(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client: %w", err)
+	}
+
+	client.Timeout = c.rpcTimeout
+
+	v, err := http.NewWithClient(addr, "/websocket", client)
+	if err != nil {
+		return nil, err
+	}
+
+	c.rpcClient, c.rpcHTTP = v, client
+	return v, nil
+}
+
+// resetHTTP discards the cached RPC client, closing its idle connections, so that the next call to HTTP
+// uses the current RPC address and timeout.
+func (c *Client) resetHTTP() {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.rpcHTTP != nil {
+		c.rpcHTTP.CloseIdleConnections()
+	}
+
+	c.rpcClient, c.rpcHTTP = nil, nil
+}
+
+// Close releases the resources held by the client, closing the cached RPC and gRPC connections.
+// The client must not be used after Close; operations that need the RPC client return ErrClientClosed.
+// It is safe to call Close multiple times.
+func (c *Client) Close() error {
+	c.resetHTTP()
+
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	c.closed = true
+
+	// Close the cached gRPC connection, if any.
+	if c.grpcConn != nil {
+		conn := c.grpcConn
+		c.grpcConn = nil
+		if err := conn.Close(); err != nil {
+			return fmt.Errorf("failed to close grpc connection: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // NewClientFromConfig creates a new Client instance based on the provided configuration.
@@ -213,7 +573,7 @@ func NewClientFromConfig(c *config.Config) (*Client, error) {
 		WithQueryProve(c.Query.GetProve()).
 		WithQueryRetryAttempts(c.Query.GetRetryAttempts()).
 		WithQueryRetryDelay(c.Query.GetRetryDelay()).
-		WithRPCAddr(c.RPC.GetAddrs()[0]).
+		WithRPCAddrs(c.RPC.GetAddrs()).
 		WithRPCChainID(c.RPC.GetChainID()).
 		WithRPCTimeout(c.RPC.GetTimeout()).
 		WithTxAuthzGranterAddr(c.Tx.GetAuthzGranterAddr()).
@@ -229,6 +589,7 @@ func NewClientFromConfig(c *config.Config) (*Client, error) {
 		WithTxQueryRetryAttempts(c.Tx.GetQueryRetryAttempts()).
 		WithTxQueryRetryDelay(c.Tx.GetQueryRetryDelay()).
 		WithTxSimulateAndExecute(c.Tx.GetSimulateAndExecute()).
+		WithTxSkipConfirmation(c.Tx.GetSkipConfirmation()).
 		WithTxTimeoutHeight(0)
 
 	// Setup the keyring for the client
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// contextKey is the type of the context keys holding per-call transaction overrides.
+type contextKey int
+
+const (
+	contextKeyAccount contextKey = iota
+	contextKeyFees
+	contextKeyGasLimit
+	contextKeyGasPrices
+	contextKeyMemo
+	contextKeyMemoData
+	contextKeySkipConfirmation
+	contextKeyTimeoutHeight
+	contextKeyTxOptions
+)
+
+// accountOverride holds the account number and sequence set by WithAccount.
+type accountOverride struct {
+	number   uint64
+	sequence uint64
+}
+
+// WithAccount returns a context that sets the account number and sequence of the sender for calls made with it,
+// instead of querying the account from the chain. It allows transactions to be generated offline.
+func WithAccount(ctx context.Context, number, sequence uint64) context.Context {
+	return context.WithValue(ctx, contextKeyAccount, accountOverride{number: number, sequence: sequence})
+}
+
+// WithFees returns a context that overrides the transaction fees of the client for calls made with it.
+// The fees are used as given instead of being calculated from the gas prices.
+func WithFees(ctx context.Context, fees cosmossdk.Coins) context.Context {
+	return context.WithValue(ctx, contextKeyFees, fees)
+}
+
+// WithGasLimit returns a context that overrides the transaction gas limit of the client for calls made with it.
+// Simulation is skipped for such calls, since the gas limit is given explicitly.
+func WithGasLimit(ctx context.Context, gas uint64) context.Context {
+	return context.WithValue(ctx, contextKeyGasLimit, gas)
+}
+
+// WithGasPrices returns a context that overrides the transaction gas prices of the client for calls made with it.
+func WithGasPrices(ctx context.Context, prices cosmossdk.DecCoins) context.Context {
+	return context.WithValue(ctx, contextKeyGasPrices, prices)
+}
+
+// WithMemo returns a context that overrides the transaction memo of the client for calls made with it.
+func WithMemo(ctx context.Context, memo string) context.Context {
+	return context.WithValue(ctx, contextKeyMemo, memo)
+}
+
+// WithMemoData returns a context holding the data that the memo template of the client is rendered with
+// for calls made with it. See Client.WithTxMemoTemplate.
+func WithMemoData(ctx context.Context, data map[string]interface{}) context.Context {
+	return context.WithValue(ctx, contextKeyMemoData, data)
+}
+
+// WithSkipConfirmation returns a context that overrides whether BroadcastTxInteractive broadcasts without
+// asking for confirmation, as CLI tools do for their --yes flag. See Client.WithTxSkipConfirmation.
+func WithSkipConfirmation(ctx context.Context, skip bool) context.Context {
+	return context.WithValue(ctx, contextKeySkipConfirmation, skip)
+}
+
+// WithTimeoutHeight returns a context that overrides the transaction timeout height of the client for calls made with it.
+func WithTimeoutHeight(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, contextKeyTimeoutHeight, height)
+}
+
+// AccountFromContext returns the account number and sequence override of the context, if any.
+func AccountFromContext(ctx context.Context) (number, sequence uint64, ok bool) {
+	v, ok := ctx.Value(contextKeyAccount).(accountOverride)
+	return v.number, v.sequence, ok
+}
+
+// FeesFromContext returns the transaction fees override of the context, if any.
+func FeesFromContext(ctx context.Context) (cosmossdk.Coins, bool) {
+	v, ok := ctx.Value(contextKeyFees).(cosmossdk.Coins)
+	return v, ok
+}
+
+// GasLimitFromContext returns the transaction gas limit override of the context, if any.
+func GasLimitFromContext(ctx context.Context) (uint64, bool) {
+	v, ok := ctx.Value(contextKeyGasLimit).(uint64)
+	return v, ok
+}
+
+// GasPricesFromContext returns the transaction gas prices override of the context, if any.
+func GasPricesFromContext(ctx context.Context) (cosmossdk.DecCoins, bool) {
+	v, ok := ctx.Value(contextKeyGasPrices).(cosmossdk.DecCoins)
+	return v, ok
+}
+
+// MemoFromContext returns the transaction memo override of the context, if any.
+func MemoFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(contextKeyMemo).(string)
+	return v, ok
+}
+
+// MemoDataFromContext returns the memo template data of the context, if any.
+func MemoDataFromContext(ctx context.Context) map[string]interface{} {
+	v, _ := ctx.Value(contextKeyMemoData).(map[string]interface{})
+	return v
+}
+
+// SkipConfirmationFromContext returns the interactive broadcast confirmation override of the context, if any.
+func SkipConfirmationFromContext(ctx context.Context) (bool, bool) {
+	v, ok := ctx.Value(contextKeySkipConfirmation).(bool)
+	return v, ok
+}
+
+// TimeoutHeightFromContext returns the transaction timeout height override of the context, if any.
+func TimeoutHeightFromContext(ctx context.Context) (uint64, bool) {
+	v, ok := ctx.Value(contextKeyTimeoutHeight).(uint64)
+	return v, ok
+}
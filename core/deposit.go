@@ -23,7 +23,7 @@ func (c *Client) Deposit(ctx context.Context, accAddr types.AccAddress) (res *v1
 	)
 
 	// Perform the gRPC query to fetch the deposit details.
-	if err := c.QueryGRPC(ctx, methodQueryDeposit, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryDeposit, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -41,7 +41,7 @@ func (c *Client) Deposits(ctx context.Context, pageReq *query.PageRequest) (res
 	)
 
 	// Perform the gRPC query to fetch the deposits.
-	if err := c.QueryGRPC(ctx, methodQueryDeposits, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryDeposits, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
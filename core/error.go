@@ -6,9 +6,19 @@ import (
 	"strings"
 )
 
+// ErrInvalidSignature is returned when a signature does not match the signed data and public key.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrFeePayerNotSigner is returned when preparing a transaction to be signed whose fee payer is distinct from
+// the sender, since the client only holds the key of the sender.
+var ErrFeePayerNotSigner = errors.New("fee payer is not the tx signer")
+
 // ErrNotFound is a predefined error representing a "not found" state.
 var ErrNotFound = errors.New("not found")
 
+// ErrTxDeclined is returned when the user declines to broadcast a transaction.
+var ErrTxDeclined = errors.New("tx declined")
+
 // newErrNotFound wraps an existing error with the predefined ErrNotFound,
 func newErrNotFound(err error) error {
 	return fmt.Errorf("%w: %v", ErrNotFound, err)
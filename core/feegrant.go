@@ -28,7 +28,7 @@ func (c *Client) FeegrantAllowance(ctx context.Context, granter, grantee types.A
 	)
 
 	// Perform the gRPC query to fetch the fee grant allowance.
-	if err := c.QueryGRPC(ctx, methodQueryFeegrantAllowance, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryFeegrantAllowance, req, &resp); err != nil {
 		if strings.Contains(err.Error(), "fee-grant not found") {
 			return nil, nil
 		}
@@ -51,7 +51,7 @@ func (c *Client) FeegrantAllowances(ctx context.Context, grantee types.AccAddres
 	)
 
 	// Perform the gRPC query to fetch the fee grants for the given grantee.
-	if err := c.QueryGRPC(ctx, methodQueryFeegrantAllowances, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryFeegrantAllowances, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -70,7 +70,7 @@ func (c *Client) FeegrantAllowancesByGranter(ctx context.Context, granter types.
 	)
 
 	// Perform the gRPC query to fetch the fee grants issued by the specified granter.
-	if err := c.QueryGRPC(ctx, methodQueryFeegrantAllowancesByGranter, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryFeegrantAllowancesByGranter, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
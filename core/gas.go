@@ -0,0 +1,79 @@
+package core
+
+import (
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	nodev3 "github.com/qubetics/qubetics-blockchain/v2/x/node/types/v3"
+	sessionv3 "github.com/qubetics/qubetics-blockchain/v2/x/session/types/v3"
+)
+
+// RecommendedGasHints returns the recommended gas adjustments for the vpn-module messages with predictable
+// gas usage, keyed by message type URL, for use with WithTxGasHints.
+//
+//   - MsgUpdateSessionRequest and MsgUpdateNodeStatusRequest: 1.1, since they only update fixed-size state.
+//   - MsgCancelSessionRequest: 1.15, since it also settles the session payment.
+//   - MsgStartSessionRequest: 1.2, since it creates a session and escrows its deposit.
+func RecommendedGasHints() map[string]float64 {
+	return map[string]float64{
+		cosmossdk.MsgTypeURL(&sessionv3.MsgUpdateSessionRequest{}): 1.1,
+		cosmossdk.MsgTypeURL(&nodev3.MsgUpdateNodeStatusRequest{}): 1.1,
+		cosmossdk.MsgTypeURL(&sessionv3.MsgCancelSessionRequest{}): 1.15,
+		cosmossdk.MsgTypeURL(&nodev3.MsgStartSessionRequest{}):     1.2,
+	}
+}
+
+// copyGasHints returns a copy of the gas hints, or nil if there are none.
+func copyGasHints(hints map[string]float64) map[string]float64 {
+	if len(hints) == 0 {
+		return nil
+	}
+
+	m := make(map[string]float64, len(hints))
+	for k, v := range hints {
+		m[k] = v
+	}
+
+	return m
+}
+
+// gasAdjustment returns the gas adjustment for a transaction with the given messages.
+// Messages wrapped in an authz exec message are considered individually. The highest hint is used when
+// every message has a gas hint, otherwise the global gas adjustment is used.
+func (c *Client) gasAdjustment(msgs []cosmossdk.Msg) float64 {
+	if len(c.txGasHints) == 0 || len(msgs) == 0 {
+		return c.txGasAdjustment
+	}
+
+	adjustment := 0.0
+	for _, msg := range msgs {
+		// Look up the hints of the wrapped messages for authz exec messages.
+		if execMsg, ok := msg.(*authz.MsgExec); ok {
+			inner, err := execMsg.GetMessages()
+			if err != nil || len(inner) == 0 {
+				return c.txGasAdjustment
+			}
+
+			hint := c.gasAdjustment(inner)
+			if hint > adjustment {
+				adjustment = hint
+			}
+
+			continue
+		}
+
+		hint, ok := c.txGasHints[cosmossdk.MsgTypeURL(msg)]
+		if !ok {
+			return c.txGasAdjustment
+		}
+		if hint > adjustment {
+			adjustment = hint
+		}
+	}
+
+	return adjustment
+}
+
+// adjustGas applies the gas adjustment for a transaction with the given messages to the simulated gas used.
+func (c *Client) adjustGas(msgs []cosmossdk.Msg, gasUsed uint64) uint64 {
+	return uint64(c.gasAdjustment(msgs) * float64(gasUsed))
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/cosmos/cosmos-sdk/codec"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCConn returns the gRPC connection to the configured gRPC server, creating it on first use.
+// The connection is shared by all callers, which is safe since it multiplexes concurrent requests,
+// and is closed by Close. Returns ErrClientClosed after Close, or an error if no gRPC address is set.
+func (c *Client) GRPCConn() (*grpc.ClientConn, error) {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	// Mark the client as in use, ending its configuration phase.
+	c.used.Store(true)
+
+	if c.grpcConn != nil {
+		return c.grpcConn, nil
+	}
+	if c.grpcAddr == "" {
+		return nil, errors.New("missing required fields: grpc_addr")
+	}
+
+	// The messages of the chain are gogoproto messages, so they must be encoded with the codec of the client
+	// rather than the default codec of gRPC.
+	cdc, ok := c.protoCodec.(interface{ GRPCCodec() encoding.Codec })
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec %T does not support grpc", c.protoCodec)
+	}
+
+	conn, err := grpc.NewClient(
+		c.grpcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(cdc.GRPCCodec())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+
+	c.grpcConn = conn
+	return conn, nil
+}
+
+// QueryGRPCDirect performs a gRPC query over the gRPC connection of the client, falling back to QueryGRPC,
+// which queries through ABCI, if no gRPC address is set. The method is the full gRPC method name, as for
+// QueryGRPC. It retries the query in case of failures based on the Client's retry configuration.
+func (c *Client) QueryGRPCDirect(ctx context.Context, method string, req, resp codec.ProtoMarshaler) error {
+	if c.grpcAddr == "" {
+		return c.QueryGRPC(ctx, method, req, resp)
+	}
+
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return err
+	}
+
+	// Query at the configured height, if any.
+	if c.queryHeight > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(c.queryHeight, 10))
+	}
+
+	// Define the function to invoke the method.
+	retryFunc := func() error {
+		if err := conn.Invoke(ctx, method, req, resp); err != nil {
+			return fmt.Errorf("failed to invoke grpc method: %w", err)
+		}
+
+		return nil
+	}
+
+	// Retry the query using the configured maximum retries and delay, until the context is done.
+	if err := retry.Do(
+		retryFunc,
+		retry.Attempts(c.queryRetryAttempts),
+		retry.Context(ctx),
+		retry.Delay(c.queryRetryDelay),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+	); err != nil {
+		return fmt.Errorf("query failed after retries: %w", err)
+	}
+
+	return nil
+}
@@ -25,7 +25,7 @@ func (c *Client) Lease(ctx context.Context, id uint64) (res *v1.Lease, err error
 	)
 
 	// Perform the gRPC query to fetch the lease details.
-	if err := c.QueryGRPC(ctx, methodQueryLease, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryLease, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -41,7 +41,7 @@ func (c *Client) Leases(ctx context.Context, pageReq *query.PageRequest) (res []
 	)
 
 	// Perform the gRPC query to fetch the leases.
-	if err := c.QueryGRPC(ctx, methodQueryLeases, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryLeases, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -60,7 +60,7 @@ func (c *Client) LeasesForNode(ctx context.Context, nodeAddr types.NodeAddress,
 	)
 
 	// Perform the gRPC query to fetch leases for the given node.
-	if err := c.QueryGRPC(ctx, methodQueryLeasesForNode, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryLeasesForNode, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -79,7 +79,7 @@ func (c *Client) LeasesForProvider(ctx context.Context, provAddr types.ProvAddre
 	)
 
 	// Perform the gRPC query to fetch leases for the given provider.
-	if err := c.QueryGRPC(ctx, methodQueryLeasesForProvider, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryLeasesForProvider, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	mint "github.com/cosmos/cosmos-sdk/x/mint/types"
+)
+
+const (
+	// gRPC methods for querying mint information
+	methodQueryAnnualProvisions = "/cosmos.mint.v1beta1.Query/AnnualProvisions" // Retrieve the current annual provisions
+	methodQueryInflation        = "/cosmos.mint.v1beta1.Query/Inflation"        // Retrieve the current inflation rate
+)
+
+// AnnualProvisions retrieves the current minting annual provisions value.
+// Returns the annual provisions and any error encountered.
+func (c *Client) AnnualProvisions(ctx context.Context) (res cosmossdk.Dec, err error) {
+	var (
+		resp mint.QueryAnnualProvisionsResponse
+		req  = &mint.QueryAnnualProvisionsRequest{}
+	)
+
+	// Perform the gRPC query to fetch the annual provisions.
+	if err := c.QueryGRPCDirect(ctx, methodQueryAnnualProvisions, req, &resp); err != nil {
+		return cosmossdk.Dec{}, err
+	}
+
+	return resp.AnnualProvisions, nil
+}
+
+// Inflation retrieves the current minting inflation rate.
+// Returns the inflation rate and any error encountered.
+func (c *Client) Inflation(ctx context.Context) (res cosmossdk.Dec, err error) {
+	var (
+		resp mint.QueryInflationResponse
+		req  = &mint.QueryInflationRequest{}
+	)
+
+	// Perform the gRPC query to fetch the inflation rate.
+	if err := c.QueryGRPCDirect(ctx, methodQueryInflation, req, &resp); err != nil {
+		return cosmossdk.Dec{}, err
+	}
+
+	return resp.Inflation, nil
+}
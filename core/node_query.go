@@ -25,7 +25,7 @@ func (c *Client) Node(ctx context.Context, nodeAddr types.NodeAddress) (res *v3.
 	)
 
 	// Perform the gRPC query to fetch the node details.
-	if err := c.QueryGRPC(ctx, methodQueryNode, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryNode, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -44,7 +44,7 @@ func (c *Client) Nodes(ctx context.Context, status v1.Status, pageReq *query.Pag
 	)
 
 	// Perform the gRPC query to fetch the nodes.
-	if err := c.QueryGRPC(ctx, methodQueryNodes, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryNodes, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -65,7 +65,7 @@ func (c *Client) NodesForPlan(ctx context.Context, id uint64, status v1.Status,
 	)
 
 	// Perform the gRPC query to fetch nodes for the given plan.
-	if err := c.QueryGRPC(ctx, methodQueryNodesForPlan, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryNodesForPlan, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
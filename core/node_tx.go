@@ -2,10 +2,12 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	cosmossdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/qubetics/qubetics-blockchain/v2/types"
+	v1 "github.com/qubetics/qubetics-blockchain/v2/types/v1"
 	v3 "github.com/qubetics/qubetics-blockchain/v2/x/node/types/v3"
 
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -25,7 +27,7 @@ func (c *Client) NodeStartSession(ctx context.Context, nodeAddr types.NodeAddres
 	}
 
 	// Broadcast the transaction and wait for its inclusion in a block.
-	_, res, err := c.BroadcastTxBlock(ctx, msgs...)
+	_, res, err := c.BroadcastTxBlock(ctx, msgs)
 	if err != nil {
 		return 0, fmt.Errorf("node start session tx failed: %w", err)
 	}
@@ -38,3 +40,94 @@ func (c *Client) NodeStartSession(ctx context.Context, nodeAddr types.NodeAddres
 
 	return id, nil
 }
+
+// RegisterNode registers the message from address as a new node with the given prices and remote URL.
+// On success, it returns the address of the registered node.
+func (c *Client) RegisterNode(ctx context.Context, remoteURL string, gigabytePrices, hourlyPrices cosmossdk.Coins) (types.NodeAddress, error) {
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the register request message for a node.
+	msgs := []cosmossdk.Msg{
+		v3.NewMsgRegisterNodeRequest(fromAddr, gigabytePrices, hourlyPrices, remoteURL),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	_, res, err := c.BroadcastTxBlock(ctx, msgs)
+	if err != nil {
+		return nil, fmt.Errorf("register node tx failed: %w", err)
+	}
+
+	// Extract the node address from the transaction events.
+	value, err := utils.AttributeValueFromEvents(res.TxResult.GetEvents(), &v3.EventCreate{}, "node_address")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node addr from events: %w", err)
+	}
+
+	nodeAddr, err := types.NodeAddressFromBech32(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node addr: %w", err)
+	}
+
+	return nodeAddr, nil
+}
+
+// UpdateNodeDetails updates the prices and remote URL of the node owned by the message from address.
+// Empty prices or an empty remote URL leave the corresponding on-chain values unchanged.
+func (c *Client) UpdateNodeDetails(ctx context.Context, remoteURL string, gigabytePrices, hourlyPrices cosmossdk.Coins) error {
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the update details request message for the node.
+	msgs := []cosmossdk.Msg{
+		v3.NewMsgUpdateNodeDetailsRequest(fromAddr.Bytes(), gigabytePrices, hourlyPrices, remoteURL),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	if _, _, err := c.BroadcastTxBlock(ctx, msgs); err != nil {
+		return fmt.Errorf("update node details tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetNodeRemoteURL updates only the remote URL of the node owned by the message from address.
+func (c *Client) SetNodeRemoteURL(ctx context.Context, remoteURL string) error {
+	if remoteURL == "" {
+		return errors.New("remote url cannot be empty")
+	}
+
+	return c.UpdateNodeDetails(ctx, remoteURL, nil, nil)
+}
+
+// UpdateNodeStatus updates the status of the node owned by the message from address.
+// The status must be either active or inactive.
+func (c *Client) UpdateNodeStatus(ctx context.Context, status v1.Status) error {
+	if !status.IsOneOf(v1.StatusActive, v1.StatusInactive) {
+		return fmt.Errorf("invalid node status %s", status)
+	}
+
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the update status request message for the node.
+	msgs := []cosmossdk.Msg{
+		v3.NewMsgUpdateNodeStatusRequest(fromAddr.Bytes(), status),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	if _, _, err := c.BroadcastTxBlock(ctx, msgs); err != nil {
+		return fmt.Errorf("update node status tx failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/errors"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// txAccount returns the account of the sender, built from the account number and sequence of the context
+// if set with WithAccount, otherwise queried from the chain.
+func (c *Client) txAccount(ctx context.Context, addr cosmossdk.AccAddress) (auth.AccountI, error) {
+	if number, sequence, ok := AccountFromContext(ctx); ok {
+		return auth.NewBaseAccount(addr, nil, number, sequence), nil
+	}
+
+	acc, err := c.Account(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account: %w", err)
+	}
+	if acc == nil {
+		return nil, newErrNotFound(fmt.Errorf("acconut %s does not exist", addr))
+	}
+
+	return acc, nil
+}
+
+// GenerateUnsignedTx builds a transaction for the messages like a broadcast does, including the authz exec
+// wrapper and fee granter, and returns it unsigned in the standard JSON encoding, to be signed elsewhere, such
+// as on an air-gapped machine. Only the public key of the sender is needed in the keyring.
+//
+// For offline use, set the account number and sequence with WithAccount and the gas limit with WithGasLimit,
+// so that neither the account nor a simulation is queried from the chain. The RPC address is still required
+// by Validate, but is not contacted.
+func (c *Client) GenerateUnsignedTx(ctx context.Context, msgs ...cosmossdk.Msg) ([]byte, error) {
+	// Resolve the transaction parameters for this call.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the transaction, which holds a placeholder signature used for simulation.
+	txb, _, _, err := c.buildTx(ctx, params, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the placeholder signature, as is done for transactions generated by the CLI.
+	if err := txb.SetSignatures(); err != nil {
+		return nil, fmt.Errorf("failed to clear signatures: %w", err)
+	}
+
+	buf, err := c.txConfig.TxJSONEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx: %w", err)
+	}
+
+	return buf, nil
+}
+
+// BroadcastSignedTx broadcasts a transaction signed elsewhere synchronously, failing over to the next RPC server
+// on network errors. The transaction can be given in the binary or the standard JSON encoding. It is broadcast
+// once, since retrying cannot fix its signed sequence, and an account sequence mismatch reported by CheckTx is
+// returned as an error for which IsWrongSequenceError reports true, so that the caller can sign it again.
+func (c *Client) BroadcastSignedTx(ctx context.Context, txBytes []byte) (*core.ResultBroadcastTx, error) {
+	// Decode the transaction, accepting the JSON encoding, and re-encode it into its binary form.
+	tx, err := c.txConfig.TxDecoder()(txBytes)
+	if err != nil {
+		tx, err = c.txConfig.TxJSONDecoder()(txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tx: %w", err)
+		}
+	}
+
+	buf, err := c.txConfig.TxEncoder()(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx: %w", err)
+	}
+
+	// Broadcast the transaction once, without retrying on account sequence mismatches.
+	res, err := c.broadcastTxBytes(ctx, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report an account sequence mismatch, which requires the transaction to be signed again.
+	if isWrongSequenceResult(res) {
+		return nil, fmt.Errorf("%s: %w", res.Log, errors.ErrWrongSequence)
+	}
+
+	return res, nil
+}
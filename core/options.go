@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TxOptions holds per-call transaction parameters that override the client settings for a single broadcast.
+// Unset fields keep the client settings, or the overrides of the context if any.
+type TxOptions struct {
+	Fees          cosmossdk.Coins    // Fees overrides the fees if not nil, without calculating them from the gas prices.
+	Gas           *uint64            // Gas overrides the gas limit if not nil, skipping simulation.
+	GasPrices     cosmossdk.DecCoins // GasPrices overrides the gas prices used to calculate the fees if not nil.
+	Memo          *string            // Memo overrides the memo if not nil.
+	TimeoutHeight *uint64            // TimeoutHeight overrides the timeout height if not nil.
+}
+
+// TxOption sets a per-call transaction parameter on TxOptions, for example
+// c.BroadcastTxSync(ctx, msgs, TxMemo("session-123"), TxGas(300_000)).
+type TxOption func(*TxOptions)
+
+// NewTxOptions returns the TxOptions set by the given options.
+func NewTxOptions(opts ...TxOption) TxOptions {
+	var o TxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// TxFees returns a TxOption overriding the fees of the transaction.
+func TxFees(fees cosmossdk.Coins) TxOption {
+	return func(o *TxOptions) { o.Fees = fees }
+}
+
+// TxGas returns a TxOption overriding the gas limit of the transaction.
+func TxGas(gas uint64) TxOption {
+	return func(o *TxOptions) { o.Gas = &gas }
+}
+
+// TxGasPrices returns a TxOption overriding the gas prices of the transaction.
+func TxGasPrices(prices cosmossdk.DecCoins) TxOption {
+	return func(o *TxOptions) { o.GasPrices = prices }
+}
+
+// TxMemo returns a TxOption overriding the memo of the transaction.
+func TxMemo(memo string) TxOption {
+	return func(o *TxOptions) { o.Memo = &memo }
+}
+
+// TxTimeoutHeight returns a TxOption overriding the timeout height of the transaction.
+func TxTimeoutHeight(height uint64) TxOption {
+	return func(o *TxOptions) { o.TimeoutHeight = &height }
+}
+
+// apply sets the parameters given by the options on p.
+func (o *TxOptions) apply(p *txParams) {
+	if o.Fees != nil {
+		p.fees, p.feesOverride = o.Fees, true
+	}
+	if o.Gas != nil {
+		p.gas, p.gasOverride = *o.Gas, true
+	}
+	if o.GasPrices != nil {
+		p.gasPrices = o.GasPrices
+	}
+	if o.Memo != nil {
+		p.memo = *o.Memo
+	}
+	if o.TimeoutHeight != nil {
+		p.timeoutHeight = *o.TimeoutHeight
+	}
+}
+
+// withTxOptions returns a context carrying the options to txParams, after the options already carried by ctx.
+func withTxOptions(ctx context.Context, opts TxOptions) context.Context {
+	items, _ := ctx.Value(contextKeyTxOptions).([]TxOptions)
+	items = append(items[:len(items):len(items)], opts)
+
+	return context.WithValue(ctx, contextKeyTxOptions, items)
+}
+
+// BroadcastTxSyncWithOptions broadcasts a transaction synchronously like BroadcastTxSync, with the options
+// overriding the client settings for this call only. It is safe to call concurrently with different options.
+func (c *Client) BroadcastTxSyncWithOptions(ctx context.Context, opts TxOptions, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+	return c.BroadcastTxSync(withTxOptions(ctx, opts), msgs)
+}
+
+// BroadcastTxBlockWithOptions broadcasts a transaction and waits for its inclusion like BroadcastTxBlock, with the
+// options overriding the client settings for this call only.
+func (c *Client) BroadcastTxBlockWithOptions(ctx context.Context, opts TxOptions, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, *core.ResultTx, error) {
+	return c.BroadcastTxBlock(withTxOptions(ctx, opts), msgs)
+}
+
+// txParams holds the transaction parameters resolved for a single call, so that concurrent callers using
+// different overrides on the same Client do not interfere.
+type txParams struct {
+	fees          cosmossdk.Coins
+	feesOverride  bool
+	gas           uint64
+	gasOverride   bool
+	gasPrices     cosmossdk.DecCoins
+	memo          string
+	sign          bool // Whether the client signs the transaction, which requires the sender to pay the fees.
+	simulate      bool
+	timeoutHeight uint64
+}
+
+// txParams resolves the transaction parameters for a call, applying the per-call overrides of the context
+// over the client settings, and the TxOptions of the call over both.
+func (c *Client) txParams(ctx context.Context) (*txParams, error) {
+	memo, err := c.memo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &txParams{
+		fees:          c.txFees,
+		gas:           c.txGas,
+		gasPrices:     c.gasPrices(ctx),
+		memo:          memo,
+		timeoutHeight: c.txTimeoutHeight,
+	}
+
+	if fees, ok := FeesFromContext(ctx); ok {
+		p.fees, p.feesOverride = fees, true
+	}
+	if gas, ok := GasLimitFromContext(ctx); ok {
+		p.gas, p.gasOverride = gas, true
+	}
+	if height, ok := TimeoutHeightFromContext(ctx); ok {
+		p.timeoutHeight = height
+	}
+
+	// Apply the options of the call over the overrides of the context.
+	items, _ := ctx.Value(contextKeyTxOptions).([]TxOptions)
+	for i := range items {
+		items[i].apply(p)
+	}
+
+	p.simulate = c.txSimulateAndExecute && !p.gasOverride
+
+	return p, nil
+}
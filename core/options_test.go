@@ -0,0 +1,91 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/qubetics/qubetics-go-sdk/core"
+	"github.com/qubetics/qubetics-go-sdk/coretest"
+)
+
+func TestBroadcastTxSyncOptions(t *testing.T) {
+	c, chain := coretest.NewOfflineClient(t)
+
+	addr := coretest.Addr(t, c)
+	msgs := []cosmossdk.Msg{bank.NewMsgSend(addr, addr, cosmossdk.NewCoins(cosmossdk.NewInt64Coin("tics", 1)))}
+
+	memo := "session-123"
+	gas := uint64(300_000)
+
+	tests := []struct {
+		name      string
+		broadcast func(ctx context.Context) error
+		wantMemo  string
+		wantGas   uint64
+	}{
+		{
+			name: "functional options",
+			broadcast: func(ctx context.Context) error {
+				_, err := c.BroadcastTxSync(ctx, msgs, core.TxMemo(memo), core.TxGas(gas))
+				return err
+			},
+			wantMemo: memo,
+			wantGas:  gas,
+		},
+		{
+			name: "options struct",
+			broadcast: func(ctx context.Context) error {
+				_, err := c.BroadcastTxSyncWithOptions(ctx, core.TxOptions{Memo: &memo, Gas: &gas}, msgs...)
+				return err
+			},
+			wantMemo: memo,
+			wantGas:  gas,
+		},
+		{
+			name: "options over context",
+			broadcast: func(ctx context.Context) error {
+				ctx = core.WithMemo(ctx, "context")
+				_, err := c.BroadcastTxSync(ctx, msgs, core.TxMemo(memo))
+				return err
+			},
+			wantMemo: memo,
+			wantGas:  coretest.SimulatedGas,
+		},
+		{
+			name: "client settings",
+			broadcast: func(ctx context.Context) error {
+				_, err := c.BroadcastTxSync(ctx, msgs)
+				return err
+			},
+			wantGas: coretest.SimulatedGas,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.broadcast(context.Background()); err != nil {
+				t.Fatalf("broadcast error = %v", err)
+			}
+
+			broadcasts := chain.Broadcasts()
+			if len(broadcasts) != i+1 {
+				t.Fatalf("got %d broadcasts, want %d", len(broadcasts), i+1)
+			}
+
+			tx, ok := broadcasts[i].Tx.(authsigning.Tx)
+			if !ok {
+				t.Fatalf("tx of type %T does not implement authsigning.Tx", broadcasts[i].Tx)
+			}
+			if got := tx.GetMemo(); got != tt.wantMemo {
+				t.Errorf("memo = %q, want %q", got, tt.wantMemo)
+			}
+			if got := tx.GetGas(); got != tt.wantGas {
+				t.Errorf("gas = %d, want %d", got, tt.wantGas)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	mint "github.com/cosmos/cosmos-sdk/x/mint/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// paramsQuery holds the gRPC method and request message used to query the parameters of a module.
+type paramsQuery struct {
+	method string
+	req    codec.ProtoMarshaler
+}
+
+// paramsQueries maps supported module names to their parameter queries.
+var paramsQueries = map[string]paramsQuery{
+	"auth":         {"/cosmos.auth.v1beta1.Query/Params", &auth.QueryParamsRequest{}},
+	"bank":         {"/cosmos.bank.v1beta1.Query/Params", &bank.QueryParamsRequest{}},
+	"distribution": {"/cosmos.distribution.v1beta1.Query/Params", &distribution.QueryParamsRequest{}},
+	// The gov module requires a params type, but always returns the full params alongside it.
+	"gov":      {"/cosmos.gov.v1.Query/Params", &gov.QueryParamsRequest{ParamsType: gov.ParamVoting}},
+	"mint":     {"/cosmos.mint.v1beta1.Query/Params", &mint.QueryParamsRequest{}},
+	"slashing": {"/cosmos.slashing.v1beta1.Query/Params", &slashing.QueryParamsRequest{}},
+	"staking":  {"/cosmos.staking.v1beta1.Query/Params", &staking.QueryParamsRequest{}},
+}
+
+// Params retrieves the parameters of the given module and unmarshals them into target,
+// which must be the module's QueryParamsResponse type (e.g. staking.QueryParamsResponse).
+// Returns an error if the module is not supported or the query fails.
+func (c *Client) Params(ctx context.Context, module string, target codec.ProtoMarshaler) error {
+	q, ok := paramsQueries[strings.ToLower(module)]
+	if !ok {
+		return fmt.Errorf("unsupported module %s", module)
+	}
+
+	// Perform the gRPC query to fetch the module parameters.
+	if err := c.QueryGRPCDirect(ctx, q.method, q.req, target); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -24,7 +24,7 @@ func (c *Client) Provider(ctx context.Context, provAddr types.ProvAddress) (res
 	)
 
 	// Perform the gRPC query to fetch the provider details.
-	if err := c.QueryGRPC(ctx, methodQueryProvider, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryProvider, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -43,7 +43,7 @@ func (c *Client) Providers(ctx context.Context, status v1.Status, pageReq *query
 	)
 
 	// Perform the gRPC query to fetch the providers.
-	if err := c.QueryGRPC(ctx, methodQueryProviders, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQueryProviders, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
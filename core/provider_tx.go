@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/qubetics/qubetics-blockchain/v2/types"
+	v2 "github.com/qubetics/qubetics-blockchain/v2/x/provider/types/v2"
+
+	"github.com/qubetics/qubetics-go-sdk/utils"
+)
+
+// RegisterProvider registers the message from address as a new provider with the given details.
+// On success, it returns the address of the registered provider.
+func (c *Client) RegisterProvider(ctx context.Context, name, identity, website, description string) (types.ProvAddress, error) {
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the register request message for a provider.
+	msgs := []cosmossdk.Msg{
+		v2.NewMsgRegisterRequest(fromAddr, name, identity, website, description),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	_, res, err := c.BroadcastTxBlock(ctx, msgs)
+	if err != nil {
+		return nil, fmt.Errorf("register provider tx failed: %w", err)
+	}
+
+	// Extract the provider address from the transaction events.
+	value, err := utils.AttributeValueFromEvents(res.TxResult.GetEvents(), &v2.EventRegister{}, "address")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider addr from events: %w", err)
+	}
+
+	provAddr, err := types.ProvAddressFromBech32(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provider addr: %w", err)
+	}
+
+	return provAddr, nil
+}
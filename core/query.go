@@ -21,25 +21,21 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data byt
 
 	// Define the function to perform the ABCI query.
 	retryFunc := func() error {
-		// Get the RPC client for querying.
-		http, err := c.HTTP()
-		if err != nil {
-			return fmt.Errorf("failed to create rpc client: %w", err)
-		}
-
 		// Configure the query options.
 		opts := client.ABCIQueryOptions{
 			Height: c.queryHeight,
 			Prove:  c.queryProve,
 		}
 
-		// Perform the query and store the result.
-		result, err = http.ABCIQueryWithOptions(ctx, path, data, opts)
-		if err != nil {
-			return fmt.Errorf("failed to perform abci query: %w", err)
-		}
+		// Perform the query and store the result, failing over to the next RPC server on network errors.
+		return c.withRPC(ctx, func(backend Backend) (err error) {
+			result, err = backend.ABCIQueryWithOptions(ctx, path, data, opts)
+			if err != nil {
+				return fmt.Errorf("failed to perform abci query: %w", err)
+			}
 
-		return nil
+			return nil
+		})
 	}
 
 	// retryIfFunc determines whether a retry should occur based on the error.
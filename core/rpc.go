@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/cometbft/cometbft/rpc/client/http"
+)
+
+// RPCAddr returns the RPC server address currently in use, or an empty string if none is set.
+func (c *Client) RPCAddr() string {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if len(c.rpcAddrs) == 0 {
+		return ""
+	}
+
+	return c.rpcAddrs[c.rpcIndex]
+}
+
+// failover switches to the next RPC address after the given RPC client failed, discarding it.
+// Nothing is done if another request already switched away from the failed client.
+func (c *Client) failover(failed *http.HTTP) {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.closed || c.rpcClient != failed {
+		return
+	}
+
+	if c.rpcHTTP != nil {
+		c.rpcHTTP.CloseIdleConnections()
+	}
+
+	c.rpcClient, c.rpcHTTP = nil, nil
+	c.rpcIndex = (c.rpcIndex + 1) % len(c.rpcAddrs)
+}
+
+// isNetworkError reports whether the error is a network-level error, such as a refused connection or a
+// timeout, after which the request can be retried on another RPC server. Errors caused by the cancellation
+// of the context are not network errors.
+func isNetworkError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// withRPC calls fn with the RPC client of the active address, failing over to the next address and calling
+// fn again when it fails with a network-level error, until every address has been tried once.
+// Application errors, such as a wrong account sequence, are returned without failing over.
+// If a backend is set with WithBackend, fn is called once with it.
+func (c *Client) withRPC(ctx context.Context, fn func(backend Backend) error) error {
+	if c.backend != nil {
+		backend, err := c.rpcBackend()
+		if err != nil {
+			return fmt.Errorf("failed to get rpc backend: %w", err)
+		}
+
+		return fn(backend)
+	}
+
+	c.rpcMu.Lock()
+	attempts := len(c.rpcAddrs)
+	c.rpcMu.Unlock()
+
+	var err error
+	for i := 0; i < attempts || i == 0; i++ {
+		var client *http.HTTP
+		if client, err = c.HTTP(); err != nil {
+			return fmt.Errorf("failed to create rpc client: %w", err)
+		}
+		if err = fn(client); !isNetworkError(ctx, err) {
+			return err
+		}
+
+		c.failover(client)
+	}
+
+	return err
+}
+
+// HealthyHTTP returns the RPC client of the first address, starting from the active one, that responds to a
+// Status request, and makes it the active address. It returns the last error if no address responds.
+// Requests fail over on their own, so this is mainly useful to check the endpoints upfront, such as at startup.
+func (c *Client) HealthyHTTP(ctx context.Context) (*http.HTTP, error) {
+	c.rpcMu.Lock()
+	attempts := len(c.rpcAddrs)
+	c.rpcMu.Unlock()
+
+	var err error
+	for i := 0; i < attempts || i == 0; i++ {
+		var client *http.HTTP
+		if client, err = c.HTTP(); err != nil {
+			return nil, fmt.Errorf("failed to create rpc client: %w", err)
+		}
+		if _, err = client.Status(ctx); err == nil {
+			return client, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to query status: %w", err)
+		}
+
+		c.failover(client)
+	}
+
+	return nil, fmt.Errorf("no rpc server responded to status: %w", err)
+}
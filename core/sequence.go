@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/errors"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// sequenceLock returns the mutex serializing the signing and broadcasting of transactions by the account,
+// so that each transaction takes the next locally tracked sequence.
+func (c *Client) sequenceLock(addr cosmossdk.AccAddress) *sync.Mutex {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	if c.seqLocks == nil {
+		c.seqLocks = make(map[string]*sync.Mutex)
+	}
+
+	mu, ok := c.seqLocks[addr.String()]
+	if !ok {
+		mu = &sync.Mutex{}
+		c.seqLocks[addr.String()] = mu
+	}
+
+	return mu
+}
+
+// applySequence sets the sequence of the account to the locally tracked one if it is ahead, since the
+// committed sequence does not account for transactions still in the mempool.
+func (c *Client) applySequence(acc auth.AccountI) error {
+	c.seqMu.Lock()
+	next, ok := c.seqNext[acc.GetAddress().String()]
+	c.seqMu.Unlock()
+
+	if !ok || next <= acc.GetSequence() {
+		return nil
+	}
+
+	return acc.SetSequence(next)
+}
+
+// commitSequence records the sequence following the one of the account, after a transaction signed with it
+// was accepted.
+func (c *Client) commitSequence(acc auth.AccountI) {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	if c.seqNext == nil {
+		c.seqNext = make(map[string]uint64)
+	}
+
+	c.seqNext[acc.GetAddress().String()] = acc.GetSequence() + 1
+}
+
+// trackSequence updates the locally tracked sequence of the account after broadcasting a transaction signed
+// with it. An account sequence mismatch re-syncs the sequence from the chain, and is returned as an error when
+// reported by CheckTx, so that the broadcast is retried.
+func (c *Client) trackSequence(acc auth.AccountI, res *core.ResultBroadcastTx, err error) error {
+	if err != nil {
+		if IsWrongSequenceError(err) {
+			c.resetSequence(acc.GetAddress())
+		}
+
+		return err
+	}
+
+	if isWrongSequenceResult(res) {
+		c.resetSequence(acc.GetAddress())
+		return fmt.Errorf("%s: %w", res.Log, errors.ErrWrongSequence)
+	}
+	if res.Code == abci.CodeTypeOK {
+		c.commitSequence(acc)
+	}
+
+	return nil
+}
+
+// resetSequence discards the locally tracked sequence of the account, so that the next transaction uses
+// the committed sequence.
+func (c *Client) resetSequence(addr cosmossdk.AccAddress) {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	delete(c.seqNext, addr.String())
+}
+
+// ResetTxSequences discards the locally tracked account sequences, so that the next transaction of each
+// account uses its committed sequence.
+func (c *Client) ResetTxSequences() {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	c.seqNext = nil
+}
+
+// isWrongSequenceResult reports whether the transaction was rejected by CheckTx for an account sequence mismatch.
+func isWrongSequenceResult(res *core.ResultBroadcastTx) bool {
+	return res.Codespace == errors.ErrWrongSequence.Codespace() && res.Code == errors.ErrWrongSequence.ABCICode()
+}
@@ -28,7 +28,7 @@ func (c *Client) Session(ctx context.Context, id uint64) (res v3.Session, err er
 	)
 
 	// Perform the gRPC query to fetch the session details.
-	if err := c.QueryGRPC(ctx, methodQuerySession, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySession, req, &resp); err != nil {
 		return nil, IsCodeNotFound(err)
 	}
 
@@ -49,7 +49,7 @@ func (c *Client) Sessions(ctx context.Context, pageReq *query.PageRequest) (res
 	)
 
 	// Perform the gRPC query to fetch the sessions.
-	if err := c.QueryGRPC(ctx, methodQuerySessions, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySessions, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -76,7 +76,7 @@ func (c *Client) SessionsForAccount(ctx context.Context, accAddr cosmossdk.AccAd
 	)
 
 	// Perform the gRPC query to fetch sessions for the given account.
-	if err := c.QueryGRPC(ctx, methodQuerySessionsForAccount, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySessionsForAccount, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -103,7 +103,7 @@ func (c *Client) SessionsForNode(ctx context.Context, nodeAddr qubetics.NodeAddr
 	)
 
 	// Perform the gRPC query to fetch sessions for the given node.
-	if err := c.QueryGRPC(ctx, methodQuerySessionsForNode, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySessionsForNode, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -130,7 +130,7 @@ func (c *Client) SessionsForSubscription(ctx context.Context, id uint64, pageReq
 	)
 
 	// Perform the gRPC query to fetch sessions for the given subscription.
-	if err := c.QueryGRPC(ctx, methodQuerySessionsForSubscription, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySessionsForSubscription, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
@@ -158,7 +158,7 @@ func (c *Client) SessionsForSubscriptionAllocation(ctx context.Context, id uint6
 	)
 
 	// Perform the gRPC query to fetch sessions for the given subscription and account.
-	if err := c.QueryGRPC(ctx, methodQuerySessionsForSubscriptionAllocation, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodQuerySessionsForSubscriptionAllocation, req, &resp); err != nil {
 		return nil, nil, err
 	}
 
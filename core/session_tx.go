@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	v3 "github.com/qubetics/qubetics-blockchain/v2/x/session/types/v3"
+)
+
+// SessionUpdate holds the cumulative usage of a session to be submitted on-chain.
+type SessionUpdate struct {
+	ID       uint64        // ID of the session.
+	Download int64         // Total downloaded bytes of the session.
+	Upload   int64         // Total uploaded bytes of the session.
+	Duration time.Duration // Total duration of the session.
+}
+
+// Validate ensures the byte and duration values are non-negative.
+func (u *SessionUpdate) Validate() error {
+	if u.Upload < 0 {
+		return errors.New("upload cannot be negative")
+	}
+	if u.Download < 0 {
+		return errors.New("download cannot be negative")
+	}
+	if u.Duration < 0 {
+		return errors.New("duration cannot be negative")
+	}
+
+	return nil
+}
+
+// UpdateSession submits the bandwidth and duration of a session served by the node of the message from address.
+// The values are cumulative for the session and must be non-negative.
+func (c *Client) UpdateSession(ctx context.Context, id uint64, upload, download int64, duration time.Duration) error {
+	return c.UpdateSessions(ctx, SessionUpdate{
+		ID:       id,
+		Download: download,
+		Upload:   upload,
+		Duration: duration,
+	})
+}
+
+// UpdateSessions submits the usage of multiple sessions served by the node of the message from address in a single transaction.
+func (c *Client) UpdateSessions(ctx context.Context, updates ...SessionUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the session update request messages signed by the node.
+	msgs := make([]cosmossdk.Msg, 0, len(updates))
+	for _, u := range updates {
+		if err := u.Validate(); err != nil {
+			return fmt.Errorf("invalid update for session %d: %w", u.ID, err)
+		}
+
+		msgs = append(msgs, v3.NewMsgUpdateSessionRequest(fromAddr.Bytes(), u.ID, u.Download, u.Upload, u.Duration, nil))
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	if _, _, err := c.BroadcastTxBlock(ctx, msgs); err != nil {
+		return fmt.Errorf("update session tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// EndSession ends the session with the specified ID.
+func (c *Client) EndSession(ctx context.Context, id uint64) error {
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the session cancel request message.
+	msgs := []cosmossdk.Msg{
+		v3.NewMsgCancelSessionRequest(fromAddr, id),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	if _, _, err := c.BroadcastTxBlock(ctx, msgs); err != nil {
+		return fmt.Errorf("end session tx failed: %w", err)
+	}
+
+	return nil
+}
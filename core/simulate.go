@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 )
 
@@ -21,9 +24,125 @@ func (c *Client) Simulate(ctx context.Context, buf []byte) (*tx.SimulateResponse
 	)
 
 	// Perform a gRPC query to simulate the transaction.
-	if err := c.QueryGRPC(ctx, methodSimulate, req, &resp); err != nil {
+	if err := c.QueryGRPCDirect(ctx, methodSimulate, req, &resp); err != nil {
 		return nil, fmt.Errorf("failed to query simulate: %w", err)
 	}
 
 	return &resp, nil
 }
+
+// FeeEstimate represents the estimated gas and fees of a transaction.
+type FeeEstimate struct {
+	Fees cosmossdk.Coins // Fees is the fee amount of the transaction.
+	Gas  uint64          // Gas is the gas limit of the transaction.
+	Msgs []cosmossdk.Msg // Msgs are the messages of the transaction, including any authz exec wrapper.
+}
+
+// EstimateFees builds a transaction for the messages and returns its gas limit and fees, simulating it
+// to estimate the gas unless the gas limit is overridden through the context. Nothing is broadcast.
+func (c *Client) EstimateFees(ctx context.Context, msgs ...cosmossdk.Msg) (*FeeEstimate, error) {
+	// Resolve the transaction parameters for this call.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the unsigned transaction, which is simulated if the client simulates before executing.
+	txb, _, _, err := c.buildTx(ctx, params, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Simulate the transaction if it was not simulated while building it.
+	if !params.gasOverride && !params.simulate {
+		gasLimit, err := c.gasSimulateTx(ctx, txb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate tx for gas estimation: %w", err)
+		}
+
+		txb.SetGasLimit(gasLimit)
+
+		if !params.feesOverride && !params.gasPrices.IsZero() {
+			txb.SetFeeAmount(calculateFees(params.gasPrices, gasLimit))
+		}
+	}
+
+	tx := txb.GetTx()
+	return &FeeEstimate{
+		Fees: tx.GetFee(),
+		Gas:  tx.GetGas(),
+		Msgs: tx.GetMsgs(),
+	}, nil
+}
+
+// SimulateResult represents the outcome of simulating a transaction.
+type SimulateResult struct {
+	Events       []abci.Event      // Events are the events emitted by the simulated execution.
+	Fees         cosmossdk.Coins   // Fees are the fees of the transaction for its gas limit.
+	GasUsed      uint64            // GasUsed is the gas used by the simulated execution.
+	GasWanted    uint64            // GasWanted is the gas limit of the transaction, after adjustment.
+	MsgResponses []*codectypes.Any // MsgResponses are the responses of the messages.
+	Msgs         []cosmossdk.Msg   // Msgs are the messages of the transaction, including any authz exec wrapper.
+}
+
+// SimulateTx builds a transaction for the messages like a broadcast does, including the authz exec wrapper
+// and fee granter, and simulates it with a placeholder signature. It returns the gas used, the gas limit after
+// adjustment, the fees for that gas limit at the configured gas prices, and the events and message responses
+// of the simulation. Gas limit and fee overrides of the context are honored. Nothing is signed or broadcast.
+func (c *Client) SimulateTx(ctx context.Context, msgs ...cosmossdk.Msg) (*SimulateResult, error) {
+	// Resolve the transaction parameters for this call. The transaction is simulated below, so it is not
+	// simulated while building it.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.simulate = false
+
+	// Build the unsigned transaction with the placeholder signature.
+	txb, _, _, err := c.buildTx(ctx, params, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the placeholder signature of a distinct fee payer, which is a required signer.
+	if err := c.setFeePayerPlaceholder(ctx, txb); err != nil {
+		return nil, err
+	}
+
+	buf, err := c.txConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx: %w", err)
+	}
+
+	// Simulate the transaction execution.
+	res, err := c.Simulate(ctx, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx: %w", err)
+	}
+
+	// Compute the gas limit and fees the transaction would be broadcast with.
+	gasUsed := res.GetGasInfo().GetGasUsed()
+
+	gasWanted := params.gas
+	if !params.gasOverride {
+		gasWanted = c.adjustGas(txb.GetTx().GetMsgs(), gasUsed)
+	}
+
+	fees := params.fees
+	if !params.feesOverride && !params.gasPrices.IsZero() {
+		fees = calculateFees(params.gasPrices, gasWanted)
+	}
+
+	result := &SimulateResult{
+		Fees:      fees,
+		GasUsed:   gasUsed,
+		GasWanted: gasWanted,
+		Msgs:      txb.GetTx().GetMsgs(),
+	}
+	if v := res.GetResult(); v != nil {
+		result.Events, result.MsgResponses = v.Events, v.MsgResponses
+	}
+
+	return result, nil
+}
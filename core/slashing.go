@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+)
+
+const (
+	// gRPC methods for querying validator signing information
+	methodQuerySigningInfo  = "/cosmos.slashing.v1beta1.Query/SigningInfo"  // Retrieve the signing info of a specific validator
+	methodQuerySigningInfos = "/cosmos.slashing.v1beta1.Query/SigningInfos" // Retrieve the signing info of all validators
+)
+
+// SigningInfo retrieves the signing info of a specific validator by its consensus address.
+// Returns the signing info details and any error encountered.
+func (c *Client) SigningInfo(ctx context.Context, consAddr cosmossdk.ConsAddress) (res *slashing.ValidatorSigningInfo, err error) {
+	var (
+		resp slashing.QuerySigningInfoResponse
+		req  = &slashing.QuerySigningInfoRequest{ConsAddress: consAddr.String()}
+	)
+
+	// Perform the gRPC query to fetch the signing info.
+	if err := c.QueryGRPCDirect(ctx, methodQuerySigningInfo, req, &resp); err != nil {
+		return nil, IsCodeNotFound(err)
+	}
+
+	return &resp.ValSigningInfo, nil
+}
+
+// SigningInfos retrieves a paginated list of validator signing infos.
+// Returns the signing infos, pagination details, and any error encountered.
+func (c *Client) SigningInfos(ctx context.Context, pageReq *query.PageRequest) (res []slashing.ValidatorSigningInfo, pageRes *query.PageResponse, err error) {
+	var (
+		resp slashing.QuerySigningInfosResponse
+		req  = &slashing.QuerySigningInfosRequest{Pagination: pageReq}
+	)
+
+	// Perform the gRPC query to fetch the signing infos.
+	if err := c.QueryGRPCDirect(ctx, methodQuerySigningInfos, req, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Info, resp.Pagination, nil
+}
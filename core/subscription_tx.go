@@ -25,7 +25,7 @@ func (c *Client) SubscriptionStartSession(ctx context.Context, id uint64, nodeAd
 	}
 
 	// Broadcast the transaction and wait for its inclusion in a block.
-	_, res, err := c.BroadcastTxBlock(ctx, msgs...)
+	_, res, err := c.BroadcastTxBlock(ctx, msgs)
 	if err != nil {
 		return 0, fmt.Errorf("subscription start session tx failed: %w", err)
 	}
@@ -38,3 +38,32 @@ func (c *Client) SubscriptionStartSession(ctx context.Context, id uint64, nodeAd
 
 	return id, nil
 }
+
+// SubscribeToPlan starts a subscription to the specified plan, paying in the given denomination.
+// On success, it returns the subscription ID.
+func (c *Client) SubscribeToPlan(ctx context.Context, planID uint64, denom string) (uint64, error) {
+	// Retrieve the message from address.
+	fromAddr, err := c.MsgFromAddr()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get message from addr: %w", err)
+	}
+
+	// Construct the subscription start request message for the plan.
+	msgs := []cosmossdk.Msg{
+		v3.NewMsgStartSubscriptionRequest(fromAddr, planID, denom),
+	}
+
+	// Broadcast the transaction and wait for its inclusion in a block.
+	_, res, err := c.BroadcastTxBlock(ctx, msgs)
+	if err != nil {
+		return 0, fmt.Errorf("subscribe to plan tx failed: %w", err)
+	}
+
+	// Extract and return the subscription ID from the transaction events.
+	id, err := utils.IDFromEvents(res.TxResult.GetEvents(), &v3.EventCreate{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get id from events: %w", err)
+	}
+
+	return id, nil
+}
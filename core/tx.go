@@ -1,8 +1,11 @@
 package core
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/avast/retry-go/v4"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -15,6 +18,8 @@ import (
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/qubetics/qubetics-go-sdk/core/input"
 )
 
 // MsgFromAddr returns the account address from which messages will be sent.
@@ -42,8 +47,51 @@ func calculateFees(gasPrices cosmossdk.DecCoins, gasLimit uint64) cosmossdk.Coin
 	return fees
 }
 
+// setFeePayerPlaceholder adds a placeholder signature for the fee payer to the transaction if it is a
+// required signer without a signature, so that the transaction can be simulated.
+func (c *Client) setFeePayerPlaceholder(ctx context.Context, txb client.TxBuilder) error {
+	tx := txb.GetTx()
+
+	signatures, err := tx.GetSignaturesV2()
+	if err != nil {
+		return fmt.Errorf("failed to get signatures: %w", err)
+	}
+	if len(tx.GetSigners()) <= len(signatures) {
+		return nil
+	}
+
+	// Retrieve the fee payer's account for the sequence checked by the simulation.
+	acc, err := c.Account(ctx, c.txFeePayer)
+	if err != nil {
+		return fmt.Errorf("failed to query fee payer account: %w", err)
+	}
+	if acc == nil {
+		return newErrNotFound(fmt.Errorf("acconut %s does not exist", c.txFeePayer))
+	}
+
+	signature := txsigning.SignatureV2{
+		PubKey: acc.GetPubKey(),
+		Data: &txsigning.SingleSignatureData{
+			SignMode:  c.signMode(),
+			Signature: nil,
+		},
+		Sequence: acc.GetSequence(),
+	}
+
+	if err := txb.SetSignatures(append(signatures, signature)...); err != nil {
+		return fmt.Errorf("failed to set fee payer signature: %w", err)
+	}
+
+	return nil
+}
+
 // gasSimulateTx simulates the execution of a transaction to estimate the gas usage.
 func (c *Client) gasSimulateTx(ctx context.Context, txb client.TxBuilder) (uint64, error) {
+	// Add the placeholder signature of a distinct fee payer, which is a required signer.
+	if err := c.setFeePayerPlaceholder(ctx, txb); err != nil {
+		return 0, err
+	}
+
 	// Encode the transaction into bytes.
 	buf, err := c.txConfig.TxEncoder()(txb.GetTx())
 	if err != nil {
@@ -56,12 +104,49 @@ func (c *Client) gasSimulateTx(ctx context.Context, txb client.TxBuilder) (uint6
 		return 0, fmt.Errorf("failed to simulate tx: %w", err)
 	}
 
-	// Apply the gas adjustment factor to the simulated gas used.
-	return uint64(c.txGasAdjustment * float64(res.GasInfo.GasUsed)), nil
+	// Apply the gas adjustment factor for the messages to the simulated gas used.
+	return c.adjustGas(txb.GetTx().GetMsgs(), res.GasInfo.GasUsed), nil
+}
+
+// gasPrices returns the gas prices of a transaction, which are the override of the context if any,
+// otherwise the gas prices of the client.
+func (c *Client) gasPrices(ctx context.Context) cosmossdk.DecCoins {
+	if prices, ok := GasPricesFromContext(ctx); ok {
+		return prices
+	}
+
+	return c.txGasPrices
+}
+
+// memo returns the memo of a transaction, which is the override of the context if any, otherwise the
+// memo template rendered with the data of the context if set, otherwise the static memo.
+func (c *Client) memo(ctx context.Context) (string, error) {
+	if memo, ok := MemoFromContext(ctx); ok {
+		return memo, nil
+	}
+	if c.txMemoTemplate == nil {
+		return c.txMemo, nil
+	}
+
+	var buf strings.Builder
+	if err := c.txMemoTemplate.Execute(&buf, MemoDataFromContext(ctx)); err != nil {
+		return "", fmt.Errorf("failed to render tx memo template: %w", err)
+	}
+
+	return buf.String(), nil
 }
 
 // prepareTx prepares a transaction for broadcasting by setting messages, fees, gas limit, memo, and other parameters.
-func (c *Client) prepareTx(ctx context.Context, key *keyring.Record, acc auth.AccountI, msgs ...cosmossdk.Msg) (client.TxBuilder, error) {
+// It fails with ErrFeePayerNotSigner if the transaction is to be signed by the client and its fee payer is distinct
+// from the sender. The parameters are taken from params rather than the client, so concurrent calls with different per-call
+// overrides do not interfere.
+func (c *Client) prepareTx(ctx context.Context, params *txParams, key *keyring.Record, acc auth.AccountI, msgs ...cosmossdk.Msg) (client.TxBuilder, error) {
+	// Fail before simulating a transaction to be signed by the client if a fee payer distinct from the sender
+	// is configured, since its signature cannot be added.
+	if params.sign && !c.txFeePayer.Empty() && !c.txFeePayer.Equals(acc.GetAddress()) {
+		return nil, ErrFeePayerNotSigner
+	}
+
 	// Create a new transaction builder.
 	txb := c.txConfig.NewTxBuilder()
 
@@ -71,21 +156,22 @@ func (c *Client) prepareTx(ctx context.Context, key *keyring.Record, acc auth.Ac
 	}
 
 	// Set static transaction parameters.
-	txb.SetFeeAmount(c.txFees)
+	txb.SetFeeAmount(params.fees)
 	txb.SetFeeGranter(c.txFeeGranterAddr)
-	txb.SetGasLimit(c.txGas)
-	txb.SetMemo(c.txMemo)
-	txb.SetTimeoutHeight(c.txTimeoutHeight)
-
-	// If gas prices are provided (non-zero), recalculate fees based on the gas limit.
-	if !c.txGasPrices.IsZero() {
-		fees := calculateFees(c.txGasPrices, c.txGas)
+	txb.SetFeePayer(c.txFeePayer)
+	txb.SetGasLimit(params.gas)
+	txb.SetMemo(params.memo)
+	txb.SetTimeoutHeight(params.timeoutHeight)
+
+	// If gas prices are provided (non-zero) and the fees are not overridden, recalculate fees based on the gas limit.
+	if !params.gasPrices.IsZero() && !params.feesOverride {
+		fees := calculateFees(params.gasPrices, params.gas)
 		txb.SetFeeAmount(fees)
 	}
 
 	// Prepare the initial signature data with a nil signature.
 	singleSignatureData := txsigning.SingleSignatureData{
-		SignMode:  txsigning.SignMode_SIGN_MODE_DIRECT,
+		SignMode:  c.signMode(),
 		Signature: nil,
 	}
 
@@ -107,8 +193,9 @@ func (c *Client) prepareTx(ctx context.Context, key *keyring.Record, acc auth.Ac
 		return nil, fmt.Errorf("failed to set initial signatures: %w", err)
 	}
 
-	// If simulation is enabled, simulate the transaction to recalculate the gas limit and fees.
-	if c.txSimulateAndExecute {
+	// If simulation is enabled and the gas limit is not overridden, simulate the transaction to recalculate
+	// the gas limit and fees.
+	if params.simulate {
 		gasLimit, err := c.gasSimulateTx(ctx, txb)
 		if err != nil {
 			return nil, fmt.Errorf("failed to simulate tx for gas estimation: %w", err)
@@ -117,9 +204,9 @@ func (c *Client) prepareTx(ctx context.Context, key *keyring.Record, acc auth.Ac
 		// Update the gas limit based on simulation.
 		txb.SetGasLimit(gasLimit)
 
-		// Recalculate fees if gas prices are provided.
-		if !c.txGasPrices.IsZero() {
-			fees := calculateFees(c.txGasPrices, gasLimit)
+		// Recalculate fees if gas prices are provided and the fees are not overridden.
+		if !params.gasPrices.IsZero() && !params.feesOverride {
+			fees := calculateFees(params.gasPrices, gasLimit)
 			txb.SetFeeAmount(fees)
 		}
 	}
@@ -131,7 +218,7 @@ func (c *Client) prepareTx(ctx context.Context, key *keyring.Record, acc auth.Ac
 func (c *Client) signTx(txb client.TxBuilder, key *keyring.Record, acc auth.AccountI) error {
 	// Prepare the initial signature data with a nil signature.
 	singleSignatureData := txsigning.SingleSignatureData{
-		SignMode:  txsigning.SignMode_SIGN_MODE_DIRECT,
+		SignMode:  c.signMode(),
 		Signature: nil,
 	}
 
@@ -184,23 +271,29 @@ func (c *Client) signTx(txb client.TxBuilder, key *keyring.Record, acc auth.Acco
 	return nil
 }
 
-// broadcastTxSync broadcasts a signed transaction synchronously and returns the broadcast result.
-func (c *Client) broadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+// txSigner retrieves the signing key configured by the sender name along with its address.
+func (c *Client) txSigner() (*keyring.Record, cosmossdk.AccAddress, error) {
 	// Retrieve the signing key using the configured sender name.
 	key, err := c.Key(c.txFromName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get key: %w", err)
+		return nil, nil, fmt.Errorf("failed to get key: %w", err)
 	}
 	if key == nil {
-		return nil, newErrNotFound(fmt.Errorf("key %s does not exist", c.txFromName))
+		return nil, nil, newErrNotFound(fmt.Errorf("key %s does not exist", c.txFromName))
 	}
 
 	// Get the sender's address from the key record.
 	addr, err := key.GetAddress()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get addr from key: %w", err)
+		return nil, nil, fmt.Errorf("failed to get addr from key: %w", err)
 	}
 
+	return key, addr, nil
+}
+
+// txMsgs wraps the messages in an authz exec message if an authz granter is configured and
+// validates the resulting messages.
+func (c *Client) txMsgs(addr cosmossdk.AccAddress, msgs []cosmossdk.Msg) ([]cosmossdk.Msg, error) {
 	if !c.txAuthzGranterAddr.Empty() {
 		execMsg := authz.NewMsgExec(addr, msgs)
 		msgs = []cosmossdk.Msg{&execMsg}
@@ -213,19 +306,77 @@ func (c *Client) broadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*c
 		}
 	}
 
-	// Retrieve the sender's account information from the blockchain.
-	acc, err := c.Account(ctx, addr)
+	return msgs, nil
+}
+
+// buildTx validates the client and messages, retrieves the signing key and account, and prepares
+// an unsigned transaction for the messages with the given parameters, wrapping them in an authz exec
+// message if configured.
+func (c *Client) buildTx(ctx context.Context, params *txParams, msgs ...cosmossdk.Msg) (client.TxBuilder, *keyring.Record, auth.AccountI, error) {
+	// Ensure the client is fully set up before signing.
+	if err := c.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	key, addr, err := c.txSigner()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query account: %w", err)
+		return nil, nil, nil, err
 	}
-	if acc == nil {
-		return nil, newErrNotFound(fmt.Errorf("acconut %s does not exist", addr))
+
+	msgs, err = c.txMsgs(addr, msgs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Retrieve the sender's account information, from the context override if any or from the blockchain.
+	acc, err := c.txAccount(ctx, addr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Use the locally tracked sequence if enabled.
+	if c.txSequenceTracking {
+		if err := c.applySequence(acc); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to set account sequence: %w", err)
+		}
 	}
 
 	// Prepare the transaction (set messages, fees, gas, etc.) for broadcasting.
-	txb, err := c.prepareTx(ctx, key, acc, msgs...)
+	txb, err := c.prepareTx(ctx, params, key, acc, msgs...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare tx: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to prepare tx: %w", err)
+	}
+
+	return txb, key, acc, nil
+}
+
+// broadcastTxSync broadcasts a signed transaction synchronously and returns the broadcast result.
+func (c *Client) broadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+	// Serialize the broadcasts of the account if its sequence is tracked locally, so that each one takes
+	// the next sequence.
+	if c.txSequenceTracking {
+		_, addr, err := c.txSigner()
+		if err != nil {
+			return nil, err
+		}
+
+		mu := c.sequenceLock(addr)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	// Resolve the transaction parameters for this call.
+	params, err := c.txParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.sign = true
+
+	// Build the unsigned transaction for the messages.
+	txb, key, acc, err := c.buildTx(ctx, params, msgs...)
+	if err != nil {
+		return nil, err
 	}
 
 	// Sign the transaction.
@@ -239,42 +390,41 @@ func (c *Client) broadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*c
 		return nil, fmt.Errorf("failed to encode tx: %w", err)
 	}
 
-	// Get the HTTP client for broadcasting the transaction.
-	http, err := c.HTTP()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create rpc client: %w", err)
-	}
+	// Broadcast the transaction synchronously.
+	res, err := c.broadcastTxBytes(ctx, buf)
 
-	// Broadcast the transaction synchronously via the HTTP client.
-	res, err := http.BroadcastTxSync(ctx, buf)
+	// Update the locally tracked sequence if enabled.
+	if c.txSequenceTracking {
+		err = c.trackSequence(acc, res, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to sync broadcast tx: %w", err)
+		return nil, err
 	}
 
 	return res, nil
 }
 
-// BroadcastTxSync attempts to broadcast a transaction synchronously with retry logic.
-func (c *Client) BroadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
-	var err error
-	var resp *core.ResultBroadcastTx
-
-	// Define a function to perform the transaction broadcast.
-	retryFunc := func() error {
-		// Attempt to broadcast the transaction.
-		resp, err = c.broadcastTxSync(ctx, msgs...)
+// broadcastTxBytes broadcasts an encoded transaction synchronously, failing over to the next RPC server
+// on network errors.
+func (c *Client) broadcastTxBytes(ctx context.Context, buf []byte) (*core.ResultBroadcastTx, error) {
+	var res *core.ResultBroadcastTx
+	if err := c.withRPC(ctx, func(backend Backend) (err error) {
+		res, err = backend.BroadcastTxSync(ctx, buf)
 		if err != nil {
-			// Return nil if the error is related to a mempool cache issue.
-			if IsTxInMempoolCacheError(err) {
-				return nil
-			}
-
-			return err
+			return fmt.Errorf("failed to sync broadcast tx: %w", err)
 		}
 
 		return nil
+	}); err != nil {
+		return nil, err
 	}
 
+	return res, nil
+}
+
+// retryBroadcast calls the broadcast function, retrying it on account sequence mismatches with the
+// configured broadcast retry attempts and delay.
+func (c *Client) retryBroadcast(fn func() error) error {
 	// retryIfFunc determines whether a retry should occur based on the error.
 	retryIfFunc := func(err error) bool {
 		// Retry if the error is an account sequence mismatch.
@@ -286,32 +436,115 @@ func (c *Client) BroadcastTxSync(ctx context.Context, msgs ...cosmossdk.Msg) (*c
 	}
 
 	// Retry broadcasting the transaction with defined attempts and delay.
-	if err := retry.Do(
-		retryFunc,
+	return retry.Do(
+		fn,
 		retry.Attempts(c.txBroadcastRetryAttempts),
 		retry.Delay(c.txBroadcastRetryDelay),
 		retry.DelayType(retry.FixedDelay),
 		retry.LastErrorOnly(true),
 		retry.RetryIf(retryIfFunc),
-	); err != nil {
+	)
+}
+
+// BroadcastTxSync attempts to broadcast a transaction synchronously with retry logic. The options, such as
+// TxMemo or TxGas, override the client settings for this call only.
+func (c *Client) BroadcastTxSync(ctx context.Context, msgs []cosmossdk.Msg, opts ...TxOption) (*core.ResultBroadcastTx, error) {
+	var err error
+	var resp *core.ResultBroadcastTx
+
+	if len(opts) > 0 {
+		ctx = withTxOptions(ctx, NewTxOptions(opts...))
+	}
+
+	// Define a function to perform the transaction broadcast.
+	retryFunc := func() error {
+		// Attempt to broadcast the transaction.
+		resp, err = c.broadcastTxSync(ctx, msgs...)
+		if err != nil {
+			// Return nil if the error is related to a mempool cache issue.
+			if IsTxInMempoolCacheError(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	}
+
+	if err := c.retryBroadcast(retryFunc); err != nil {
 		return nil, fmt.Errorf("tx sync broadcast failed after retries: %w", err)
 	}
 
 	return resp, nil
 }
 
-// tx retrieves a transaction from the blockchain using its hash.
-func (c *Client) tx(ctx context.Context, hash bytes.HexBytes) (*core.ResultTx, error) {
-	// Get the HTTP client for querying the blockchain.
-	http, err := c.HTTP()
+// BroadcastTxInteractive simulates the transaction, writes its messages, gas and fees to w, and broadcasts it
+// synchronously once the user confirms through the reader. The confirmed gas and fees are used for the broadcast,
+// so the transaction is not simulated twice. If confirmation is skipped with Client.WithTxSkipConfirmation or
+// WithSkipConfirmation, the transaction is broadcast with BroadcastTxSync without writing the summary.
+// ErrTxDeclined is returned if the user declines.
+func (c *Client) BroadcastTxInteractive(ctx context.Context, w io.Writer, reader *bufio.Reader, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, error) {
+	// Broadcast directly if confirmation is skipped.
+	skip := c.txSkipConfirmation
+	if v, ok := SkipConfirmationFromContext(ctx); ok {
+		skip = v
+	}
+	if skip {
+		return c.BroadcastTxSync(ctx, msgs)
+	}
+
+	// Estimate the gas and fees of the transaction.
+	estimate, err := c.EstimateFees(ctx, msgs...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create rpc client: %w", err)
+		return nil, fmt.Errorf("failed to estimate fees: %w", err)
 	}
 
-	// Perform the query using the transaction hash.
-	res, err := http.Tx(ctx, hash, c.queryProve)
+	// Write the transaction summary.
+	for _, msg := range estimate.Msgs {
+		buf, err := c.protoCodec.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode msg: %w", err)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", buf); err != nil {
+			return nil, fmt.Errorf("failed to write summary: %w", err)
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "gas: %d\nfees: %s\n", estimate.Gas, estimate.Fees); err != nil {
+		return nil, fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	// Prompt for confirmation before broadcasting.
+	ok, err := input.GetConfirmation("confirm transaction before broadcasting [y/N]: ", reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tx: %w", err)
+		return nil, fmt.Errorf("failed to get input: %w", err)
+	}
+	if !ok {
+		return nil, ErrTxDeclined
+	}
+
+	// Broadcast with the confirmed gas and fees.
+	ctx = WithGasLimit(ctx, estimate.Gas)
+	ctx = WithFees(ctx, estimate.Fees)
+
+	return c.BroadcastTxSync(ctx, msgs)
+}
+
+// tx retrieves a transaction from the blockchain using its hash.
+func (c *Client) tx(ctx context.Context, hash bytes.HexBytes) (*core.ResultTx, error) {
+	// Perform the query using the transaction hash, failing over to the next RPC server on network errors.
+	var res *core.ResultTx
+	if err := c.withRPC(ctx, func(backend Backend) (err error) {
+		res, err = backend.Tx(ctx, hash, c.queryProve)
+		if err != nil {
+			return fmt.Errorf("failed to query tx: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	return res, nil
@@ -353,11 +586,12 @@ func (c *Client) Tx(ctx context.Context, hash bytes.HexBytes) (*core.ResultTx, e
 }
 
 // BroadcastTxBlock broadcasts a transaction and waits for it to be included in a block.
-// It first calls BroadcastTxSync to send the transaction and then queries for the transaction result.
+// It first calls BroadcastTxSync to send the transaction and then waits for the transaction result with WaitTx.
 // Returns both the broadcast response and the transaction result or an error if any step fails.
-func (c *Client) BroadcastTxBlock(ctx context.Context, msgs ...cosmossdk.Msg) (*core.ResultBroadcastTx, *core.ResultTx, error) {
+// The options override the client settings for this call only, as with BroadcastTxSync.
+func (c *Client) BroadcastTxBlock(ctx context.Context, msgs []cosmossdk.Msg, opts ...TxOption) (*core.ResultBroadcastTx, *core.ResultTx, error) {
 	// Broadcast the transaction synchronously.
-	resp, err := c.BroadcastTxSync(ctx, msgs...)
+	resp, err := c.BroadcastTxSync(ctx, msgs, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -369,7 +603,7 @@ func (c *Client) BroadcastTxBlock(ctx context.Context, msgs ...cosmossdk.Msg) (*
 	}
 
 	// Wait for the transaction to be included in a block.
-	res, err := c.Tx(ctx, resp.Hash)
+	res, err := c.WaitTx(ctx, resp.Hash)
 	if err != nil {
 		return resp, nil, err
 	}
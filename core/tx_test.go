@@ -0,0 +1,41 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/qubetics/qubetics-go-sdk/core"
+	"github.com/qubetics/qubetics-go-sdk/coretest"
+)
+
+func TestBroadcastTxSyncFeePayerNotSigner(t *testing.T) {
+	c, chain := coretest.NewOfflineClient(t)
+
+	payer := cosmossdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	c = c.Clone().WithTxFeePayer(payer)
+
+	simulated := false
+	chain.HandleQuery("/cosmos.tx.v1beta1.Service/Simulate", func([]byte) (codec.ProtoMarshaler, error) {
+		simulated = true
+		return nil, errors.New("unexpected simulation")
+	})
+
+	addr := coretest.Addr(t, c)
+	msgs := []cosmossdk.Msg{bank.NewMsgSend(addr, addr, cosmossdk.NewCoins(cosmossdk.NewInt64Coin("tics", 1)))}
+
+	if _, err := c.BroadcastTxSync(context.Background(), msgs); !errors.Is(err, core.ErrFeePayerNotSigner) {
+		t.Fatalf("BroadcastTxSync() error = %v, want %v", err, core.ErrFeePayerNotSigner)
+	}
+	if simulated {
+		t.Errorf("BroadcastTxSync() simulated the tx before failing")
+	}
+	if got := len(chain.Broadcasts()); got != 0 {
+		t.Errorf("got %d broadcasts, want 0", got)
+	}
+}
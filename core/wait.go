@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/rpc/client/http"
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// TxWaitMode represents how the client waits for a transaction to be included in a block.
+type TxWaitMode string
+
+const (
+	TxWaitModePoll      TxWaitMode = "poll"      // TxWaitModePoll queries the transaction until it is found.
+	TxWaitModeSubscribe TxWaitMode = "subscribe" // TxWaitModeSubscribe subscribes to the transaction event over websocket.
+)
+
+// txSubscriber is the subscriber name of the transaction event subscriptions.
+const txSubscriber = "qubetics-go-sdk"
+
+// WaitTx waits for the transaction with the given hash to be included in a block and returns its result.
+// In TxWaitModeSubscribe, it subscribes to the transaction event over websocket and returns as soon as the
+// event arrives, falling back to polling if the subscription cannot be set up. In TxWaitModePoll, the
+// default, it queries the transaction with the configured retry attempts and delay, like Tx.
+// It returns when the context is done, removing the subscription.
+func (c *Client) WaitTx(ctx context.Context, hash bytes.HexBytes) (*core.ResultTx, error) {
+	if c.txWaitMode == TxWaitModeSubscribe {
+		res, err := c.subscribeTx(ctx, hash)
+		if err == nil || ctx.Err() != nil {
+			return res, err
+		}
+	}
+
+	return c.Tx(ctx, hash)
+}
+
+// subscribeTx waits for the transaction with the given hash over a dedicated websocket connection
+// to the active RPC server, which is closed on return.
+func (c *Client) subscribeTx(ctx context.Context, hash bytes.HexBytes) (*core.ResultTx, error) {
+	addr := c.RPCAddr()
+	if addr == "" {
+		return nil, errors.New("missing required fields: rpc_addr")
+	}
+
+	// Create an RPC client of its own, since the shared one does not run the websocket connection.
+	client, err := jsonrpcclient.DefaultHTTPClient(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client: %w", err)
+	}
+
+	client.Timeout = c.rpcTimeout
+
+	rpc, err := http.NewWithClient(addr, "/websocket", client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client: %w", err)
+	}
+	if err := rpc.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start rpc client: %w", err)
+	}
+
+	defer func() { _ = rpc.Stop() }()
+
+	// Subscribe to the event of the transaction.
+	query := fmt.Sprintf("%s='%s' AND %s='%X'", cmttypes.EventTypeKey, cmttypes.EventTx, cmttypes.TxHashKey, hash)
+	events, err := rpc.Subscribe(ctx, txSubscriber, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to tx event: %w", err)
+	}
+
+	defer func() { _ = rpc.Unsubscribe(context.Background(), txSubscriber, query) }()
+
+	// Return the transaction if it was included before the subscription was set up.
+	if res, err := c.tx(ctx, hash); err == nil {
+		return res, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case event := <-events:
+		data, ok := event.Data.(cmttypes.EventDataTx)
+		if !ok {
+			return nil, fmt.Errorf("invalid tx event data type %T", event.Data)
+		}
+
+		return &core.ResultTx{
+			Hash:     hash,
+			Height:   data.Height,
+			Index:    data.Index,
+			TxResult: data.Result,
+			Tx:       data.Tx,
+		}, nil
+	}
+}
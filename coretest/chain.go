@@ -0,0 +1,335 @@
+package coretest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	core "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+const (
+	// gRPC methods answered by default
+	methodQueryAccount = "/cosmos.auth.v1beta1.Query/Account"
+	methodSimulate     = "/cosmos.tx.v1beta1.Service/Simulate"
+)
+
+// SimulatedGas is the gas used reported by the default simulation handler of a Chain.
+const SimulatedGas = 100_000
+
+// QueryHandler answers a gRPC query made through ABCI, given the encoded request.
+// Returning an error fails the query with the error as its log, as the chain does.
+type QueryHandler func(req []byte) (codec.ProtoMarshaler, error)
+
+// CheckTxFunc decides the result of a transaction broadcast to a Chain.
+type CheckTxFunc func(b Broadcast) abci.ResponseCheckTx
+
+// Broadcast represents a transaction broadcast to a Chain.
+type Broadcast struct {
+	Hash bytes.HexBytes // Hash is the hash of the transaction.
+	Tx   cosmossdk.Tx   // Tx is the decoded transaction.
+}
+
+// Msgs returns the messages of the transaction.
+func (b Broadcast) Msgs() []cosmossdk.Msg {
+	return b.Tx.GetMsgs()
+}
+
+// Chain is a fake chain serving the RPC methods used by core.Client over HTTP, for unit tests without a live chain.
+// It also implements core.Backend, so that it can be used in-process with core.Client.WithBackend. Queries are answered by the handlers registered with HandleQuery, and broadcast transactions are recorded,
+// so that tests can assert on the messages sent. Accounts are created on first query, and their sequence is
+// incremented for each accepted transaction they sign.
+type Chain struct {
+	cdc      codec.ProtoCodecMarshaler
+	txConfig client.TxConfig
+	server   *httptest.Server
+
+	mu         sync.Mutex
+	accounts   map[string]*auth.BaseAccount
+	broadcasts []Broadcast
+	checkTx    CheckTxFunc
+	handlers   map[string]QueryHandler
+	height     int64
+	txs        map[string]*core.ResultTx
+}
+
+// newChain starts a Chain using the given codec and transaction configuration, which is stopped when the test ends.
+func newChain(t testing.TB, cdc codec.ProtoCodecMarshaler, txConfig client.TxConfig) *Chain {
+	t.Helper()
+
+	c := &Chain{
+		cdc:      cdc,
+		txConfig: txConfig,
+		accounts: make(map[string]*auth.BaseAccount),
+		handlers: make(map[string]QueryHandler),
+		height:   1,
+		txs:      make(map[string]*core.ResultTx),
+	}
+
+	// Register the default query handlers.
+	c.handlers[methodQueryAccount] = c.queryAccount
+	c.handlers[methodSimulate] = c.simulate
+
+	// Serve the RPC methods used by the client.
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"abci_query":         rpcserver.NewRPCFunc(c.abciQuery, "path,data,height,prove"),
+		"broadcast_tx_async": rpcserver.NewRPCFunc(c.broadcastTx, "tx"),
+		"broadcast_tx_sync":  rpcserver.NewRPCFunc(c.broadcastTx, "tx"),
+		"status":             rpcserver.NewRPCFunc(c.status, ""),
+		"tx":                 rpcserver.NewRPCFunc(c.tx, "hash,prove"),
+	}, log.NewNopLogger())
+
+	c.server = httptest.NewServer(mux)
+	t.Cleanup(c.server.Close)
+
+	return c
+}
+
+// URL returns the RPC address of the chain.
+func (c *Chain) URL() string {
+	return c.server.URL
+}
+
+// HandleQuery registers the handler answering the given gRPC method, such as "/cosmos.bank.v1beta1.Query/Balance",
+// replacing any previous one.
+func (c *Chain) HandleQuery(method string, handler QueryHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers[method] = handler
+}
+
+// SetCheckTx sets the function deciding the result of broadcast transactions. By default, all transactions
+// are accepted.
+func (c *Chain) SetCheckTx(fn CheckTxFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checkTx = fn
+}
+
+// Broadcasts returns the transactions broadcast to the chain, in order, including rejected ones.
+func (c *Chain) Broadcasts() []Broadcast {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Broadcast(nil), c.broadcasts...)
+}
+
+// Msgs returns the messages of all the transactions broadcast to the chain, in order.
+func (c *Chain) Msgs() []cosmossdk.Msg {
+	var msgs []cosmossdk.Msg
+	for _, b := range c.Broadcasts() {
+		msgs = append(msgs, b.Msgs()...)
+	}
+
+	return msgs
+}
+
+// Account returns the account of the given address, creating it if it does not exist.
+func (c *Chain) Account(addr cosmossdk.AccAddress) *auth.BaseAccount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.account(addr)
+}
+
+// account returns the account of the given address, creating it if it does not exist.
+// The caller must hold the lock.
+func (c *Chain) account(addr cosmossdk.AccAddress) *auth.BaseAccount {
+	acc, ok := c.accounts[addr.String()]
+	if !ok {
+		acc = auth.NewBaseAccount(addr, nil, uint64(len(c.accounts)), 0)
+		c.accounts[addr.String()] = acc
+	}
+
+	return acc
+}
+
+// queryAccount answers account queries with the account of the requested address.
+func (c *Chain) queryAccount(buf []byte) (codec.ProtoMarshaler, error) {
+	var req auth.QueryAccountRequest
+	if err := c.cdc.Unmarshal(buf, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+
+	addr, err := cosmossdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	acc, err := codectypes.NewAnyWithValue(c.Account(addr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack account: %w", err)
+	}
+
+	return &auth.QueryAccountResponse{Account: acc}, nil
+}
+
+// simulate answers simulation queries, reporting SimulatedGas as the gas used.
+func (c *Chain) simulate(_ []byte) (codec.ProtoMarshaler, error) {
+	return &tx.SimulateResponse{
+		GasInfo: &cosmossdk.GasInfo{GasUsed: SimulatedGas},
+		Result:  &cosmossdk.Result{},
+	}, nil
+}
+
+// abciQuery serves the abci_query RPC method with the registered query handlers.
+func (c *Chain) abciQuery(_ *rpctypes.Context, path string, data bytes.HexBytes, _ int64, _ bool) (*core.ResultABCIQuery, error) {
+	c.mu.Lock()
+	handler, ok := c.handlers[path]
+	height := c.height
+	c.mu.Unlock()
+
+	if !ok {
+		return &core.ResultABCIQuery{
+			Response: abci.ResponseQuery{Code: 1, Log: fmt.Sprintf("unknown query path %s", path), Height: height},
+		}, nil
+	}
+
+	resp, err := handler(data)
+	if err != nil {
+		return &core.ResultABCIQuery{
+			Response: abci.ResponseQuery{Code: 1, Log: err.Error(), Height: height},
+		}, nil
+	}
+
+	value, err := c.cdc.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return &core.ResultABCIQuery{
+		Response: abci.ResponseQuery{Value: value, Height: height},
+	}, nil
+}
+
+// broadcastTx serves the broadcast_tx_sync and broadcast_tx_async RPC methods, recording the transaction
+// and including it in a new block if it is accepted.
+func (c *Chain) broadcastTx(_ *rpctypes.Context, buf cmttypes.Tx) (*core.ResultBroadcastTx, error) {
+	v, err := c.txConfig.TxDecoder()(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+
+	hash := bytes.HexBytes(cmttypes.Tx(buf).Hash())
+	b := Broadcast{Hash: hash, Tx: v}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.broadcasts = append(c.broadcasts, b)
+
+	res := abci.ResponseCheckTx{GasWanted: int64(gasLimit(v))}
+	if c.checkTx != nil {
+		res = c.checkTx(b)
+	}
+	if res.IsOK() {
+		// Increment the sequence of the signers and include the transaction in a new block.
+		if v, ok := v.(authsigning.SigVerifiableTx); ok {
+			for _, addr := range v.GetSigners() {
+				acc := c.account(addr)
+				if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		c.height++
+		c.txs[hash.String()] = &core.ResultTx{
+			Hash:   hash,
+			Height: c.height,
+			Tx:     buf,
+			TxResult: abci.ResponseDeliverTx{
+				Code:      res.Code,
+				GasWanted: res.GasWanted,
+				GasUsed:   SimulatedGas,
+			},
+		}
+	}
+
+	return &core.ResultBroadcastTx{
+		Code:      res.Code,
+		Data:      res.Data,
+		Log:       res.Log,
+		Codespace: res.Codespace,
+		Hash:      hash,
+	}, nil
+}
+
+// status serves the status RPC method.
+func (c *Chain) status(_ *rpctypes.Context) (*core.ResultStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &core.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{Network: ChainID},
+		SyncInfo: core.SyncInfo{LatestBlockHeight: c.height},
+	}, nil
+}
+
+// tx serves the tx RPC method with the transactions included by the chain.
+func (c *Chain) tx(_ *rpctypes.Context, hash []byte, _ bool) (*core.ResultTx, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, ok := c.txs[bytes.HexBytes(hash).String()]
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+
+	return res, nil
+}
+
+// ABCIQueryWithOptions answers the query like the abci_query RPC method.
+func (c *Chain) ABCIQueryWithOptions(_ context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*core.ResultABCIQuery, error) {
+	return c.abciQuery(nil, path, data, opts.Height, opts.Prove)
+}
+
+// BroadcastTxAsync broadcasts the transaction like the broadcast_tx_async RPC method.
+func (c *Chain) BroadcastTxAsync(_ context.Context, tx cmttypes.Tx) (*core.ResultBroadcastTx, error) {
+	return c.broadcastTx(nil, tx)
+}
+
+// BroadcastTxSync broadcasts the transaction like the broadcast_tx_sync RPC method.
+func (c *Chain) BroadcastTxSync(_ context.Context, tx cmttypes.Tx) (*core.ResultBroadcastTx, error) {
+	return c.broadcastTx(nil, tx)
+}
+
+// Status returns the status of the chain like the status RPC method.
+func (c *Chain) Status(_ context.Context) (*core.ResultStatus, error) {
+	return c.status(nil)
+}
+
+// Tx returns the transaction with the given hash like the tx RPC method.
+func (c *Chain) Tx(_ context.Context, hash []byte, prove bool) (*core.ResultTx, error) {
+	return c.tx(nil, hash, prove)
+}
+
+// gasLimit returns the gas limit of the transaction, if it has one.
+func gasLimit(v cosmossdk.Tx) uint64 {
+	if v, ok := v.(cosmossdk.FeeTx); ok {
+		return v.GetGas()
+	}
+
+	return 0
+}
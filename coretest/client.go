@@ -0,0 +1,116 @@
+// Package coretest provides helpers for unit testing code built on core.Client without a live chain.
+//
+// NewClient returns a client with an in-memory keyring holding a deterministic test key, connected to a
+// fake Chain that answers queries with registered handlers and records broadcast transactions:
+//
+//	c, chain := coretest.NewClient(t)
+//	chain.HandleQuery("/cosmos.bank.v1beta1.Query/Balance", func(req []byte) (codec.ProtoMarshaler, error) {
+//		return &bank.QueryBalanceResponse{Balance: &coin}, nil
+//	})
+//
+//	// ... exercise the code under test with c ...
+//
+//	msgs := chain.Msgs()
+//
+// NewOfflineClient does the same with the chain set as the backend of the client, without network access.
+package coretest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	qubeticshd "github.com/qubetics/qubetics-blockchain/v2/crypto/hd"
+
+	"github.com/qubetics/qubetics-go-sdk/core"
+	"github.com/qubetics/qubetics-go-sdk/types"
+)
+
+var _ core.Backend = (*Chain)(nil)
+
+const (
+	// ChainID is the chain ID of the fake chain and of the clients returned by NewClient.
+	ChainID = "qubetics-test-1"
+
+	// KeyName is the name of the test key in the keyring of the clients returned by NewClient.
+	KeyName = "test"
+
+	// Mnemonic is the mnemonic of the test key, which always derives the same address.
+	Mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+)
+
+// NewChain starts a fake chain using the codec and transaction configuration of core.NewClient.
+// The chain is stopped when the test ends.
+func NewChain(t testing.TB) *Chain {
+	t.Helper()
+
+	cdc := types.NewProtoCodec()
+	return newChain(t, cdc, tx.NewTxConfig(cdc, tx.DefaultSignModes))
+}
+
+// NewClient returns a client connected to a new fake chain over HTTP, along with the chain. The client signs with
+// the test key of an in-memory keyring, makes a single attempt for each query and broadcast, and is closed when
+// the test ends.
+func NewClient(t testing.TB) (*core.Client, *Chain) {
+	t.Helper()
+
+	chain := NewChain(t)
+	return newClient(t, func(c *core.Client) { c.WithRPCAddr(chain.URL()) }), chain
+}
+
+// NewOfflineClient returns a client like NewClient, using the chain as its backend instead of connecting to it.
+func NewOfflineClient(t testing.TB) (*core.Client, *Chain) {
+	t.Helper()
+
+	chain := NewChain(t)
+	return newClient(t, func(c *core.Client) { c.WithBackend(chain) }), chain
+}
+
+// newClient returns a client set up with the test key, connected to a chain by the connect function.
+func newClient(t testing.TB, connect func(c *core.Client)) *core.Client {
+	t.Helper()
+
+	c := core.NewClient()
+	connect(c)
+	c.WithKeyring(keyring.NewInMemory(c.ProtoCodec(), qubeticshd.EthSecp256k1Option())).
+		WithQueryRetryAttempts(1).
+		WithRPCChainID(ChainID).
+		WithRPCTimeout(5 * time.Second).
+		WithTxBroadcastRetryAttempts(1).
+		WithTxFromName(KeyName).
+		WithTxGasAdjustment(1).
+		WithTxGas(SimulatedGas).
+		WithTxQueryRetryAttempts(10).
+		WithTxQueryRetryDelay(10 * time.Millisecond)
+
+	if _, _, err := c.CreateKey(KeyName, Mnemonic, "", ""); err != nil {
+		t.Fatalf("failed to create test key: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("failed to close client: %v", err)
+		}
+	})
+
+	return c
+}
+
+// Addr returns the address of the test key in the keyring of a client returned by NewClient.
+func Addr(t testing.TB, c *core.Client) cosmossdk.AccAddress {
+	t.Helper()
+
+	key, err := c.Key(KeyName)
+	if err != nil {
+		t.Fatalf("failed to get test key: %v", err)
+	}
+
+	addr, err := key.GetAddress()
+	if err != nil {
+		t.Fatalf("failed to get test key address: %v", err)
+	}
+
+	return addr
+}
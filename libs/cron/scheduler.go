@@ -1,8 +1,11 @@
 package cron
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,12 +13,17 @@ import (
 )
 
 // Scheduler manages the scheduling and execution of workers.
+//
+// A worker completes when it reaches its maximum number of runs, or when OnError returns true.
+// Completed workers, such as one-shot workers, are deleted from the scheduler rather than kept
+// in a terminal state, so they are not restarted by a later Start.
 type Scheduler struct {
-	isRunning  bool              // Indicates if the scheduler is currently running.
-	stopSignal chan struct{}     // Channel to signal workers to stop.
-	workers    map[string]Worker // Workers registered with the scheduler.
-	mu         sync.Mutex        // Mutex for synchronizing access to scheduler state.
-	wg         sync.WaitGroup    // WaitGroup to wait for all worker goroutines to complete.
+	isRunning  bool                     // Indicates if the scheduler is currently running.
+	isStopping bool                     // Indicates if a stop has been requested for the current run.
+	stopSignal chan struct{}            // Channel to signal workers to stop.
+	workers    map[string]Worker        // Workers registered with the scheduler.
+	running    map[string]chan struct{} // Exit channels of worker goroutines that have not finished yet.
+	mu         sync.Mutex               // Mutex for synchronizing access to scheduler state.
 }
 
 // NewScheduler creates and initializes a new Scheduler instance.
@@ -23,6 +31,7 @@ func NewScheduler() *Scheduler {
 	return &Scheduler{
 		stopSignal: make(chan struct{}),
 		workers:    make(map[string]Worker),
+		running:    make(map[string]chan struct{}),
 	}
 }
 
@@ -36,60 +45,150 @@ func (s *Scheduler) Start() error {
 	}
 
 	s.isRunning = true
+	s.isStopping = false
+	s.stopSignal = make(chan struct{})
 
 	// Run each worker in its own goroutine.
 	for _, w := range s.workers {
-		s.wg.Add(1)
-		go s.runWorker(w)
+		s.startWorker(w)
 	}
 
 	return nil
 }
 
-// Stop halts the execution of all workers and stops the scheduler.
-func (s *Scheduler) Stop() {
+// Stop signals all workers to stop and waits for them to exit until the context is done.
+// If some workers are still running when the context expires, an error listing them is returned
+// and the scheduler remains in the stopping state; calling Stop again resumes waiting.
+// Stop is idempotent and safe to call from multiple goroutines.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+
+	if !s.isStopping {
+		s.isStopping = true
+		close(s.stopSignal)
+	}
+
+	stopSignal := s.stopSignal
+	running := make(map[string]chan struct{}, len(s.running))
+	for name, done := range s.running {
+		running[name] = done
+	}
+	s.mu.Unlock()
+
+	// Wait for all worker goroutines to finish or the context to be done.
+	for _, done := range running {
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+	}
+
+	var pending []string
+	for name, done := range running {
+		select {
+		case <-done:
+		default:
+			pending = append(pending, name)
+		}
+	}
+
+	if len(pending) > 0 {
+		sort.Strings(pending)
+		return fmt.Errorf("workers %s failed to exit: %w", strings.Join(pending, ", "), ctx.Err())
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if !s.isRunning {
-		return
+	// Only reset the state if the scheduler has not been restarted meanwhile.
+	if s.stopSignal == stopSignal {
+		s.isRunning = false
+		s.isStopping = false
 	}
 
-	// Wait for all worker goroutines to finish.
-	close(s.stopSignal)
-	s.wg.Wait()
+	return nil
+}
+
+// StopWithTimeout is a convenience wrapper around Stop that waits at most the given timeout.
+func (s *Scheduler) StopWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	s.isRunning = false
+	return s.Stop(ctx)
 }
 
 // RegisterWorkers adds multiple workers to the scheduler.
+// If the scheduler is running, the workers are started immediately.
+// Workers that have completed are removed, so their names can be registered again.
 func (s *Scheduler) RegisterWorkers(workers ...Worker) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.isRunning {
-		return errors.New("scheduler is already running")
+	if s.isStopping {
+		return errors.New("scheduler is stopping")
 	}
 
-	for _, w := range workers {
+	for i, w := range workers {
 		if _, exists := s.workers[w.Name()]; exists {
 			return fmt.Errorf("duplicate worker %s", w.Name())
 		}
+		for _, o := range workers[:i] {
+			if o.Name() == w.Name() {
+				return fmt.Errorf("duplicate worker %s", w.Name())
+			}
+		}
+	}
 
+	for _, w := range workers {
 		s.workers[w.Name()] = w
+		if s.isRunning {
+			s.startWorker(w)
+		}
 	}
 
 	return nil
 }
 
+// startWorker runs the worker in its own goroutine and tracks its exit channel.
+// The caller must hold the scheduler mutex.
+func (s *Scheduler) startWorker(w Worker) {
+	done := make(chan struct{})
+	s.running[w.Name()] = done
+
+	go s.runWorker(w, s.stopSignal, done)
+}
+
 // runWorker continuously executes a worker's function and handles errors.
-func (s *Scheduler) runWorker(w Worker) {
+func (s *Scheduler) runWorker(w Worker, stopSignal <-chan struct{}, done chan struct{}) {
+	completed := false
+
 	defer func() {
 		w.OnExit()
-		s.wg.Done()
+
+		s.mu.Lock()
+		if s.running[w.Name()] == done {
+			delete(s.running, w.Name())
+
+			// Completed workers are unregistered so that the name can be reused.
+			if completed && s.workers[w.Name()] == w {
+				delete(s.workers, w.Name())
+			}
+		}
+		s.mu.Unlock()
+
+		close(done)
 	}()
 
-	for c := uint(0); w.MaxRuns() == 0 || c < w.MaxRuns(); c++ {
+	// Sleep until the scheduled time—or stop early if we receive a stopSignal
+	if !wait(stopSignal, time.Until(runAt(w))) {
+		return
+	}
+
+	for c := uint(1); ; c++ {
 		// Attempt the worker's run function with retries
 		if err := retry.Do(
 			w.Run,
@@ -99,14 +198,50 @@ func (s *Scheduler) runWorker(w Worker) {
 			retry.OnRetry(w.OnRetry),
 			retry.LastErrorOnly(true),
 		); err != nil && w.OnError(err) {
+			completed = true
+			return
+		}
+
+		if w.MaxRuns() != 0 && c >= w.MaxRuns() {
+			completed = true
 			return
 		}
 
 		// Sleep for the interval—or stop early if we receive a stopSignal
-		select {
-		case <-s.stopSignal:
+		if !wait(stopSignal, w.Interval()) {
 			return
-		case <-time.After(w.Interval()):
 		}
 	}
 }
+
+// runAt returns the time of the first run of the worker, which is zero unless it implements ScheduledWorker.
+func runAt(w Worker) time.Time {
+	if sw, ok := w.(ScheduledWorker); ok {
+		return sw.RunAt()
+	}
+
+	return time.Time{}
+}
+
+// wait sleeps for the given duration and reports whether it elapsed before the stop signal.
+// A non-positive duration returns immediately unless the stop signal is already closed.
+func wait(stopSignal <-chan struct{}, d time.Duration) bool {
+	if d <= 0 {
+		select {
+		case <-stopSignal:
+			return false
+		default:
+			return true
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-stopSignal:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
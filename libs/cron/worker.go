@@ -17,8 +17,17 @@ type Worker interface {
 	Run() error                      // Executes the worker and returns an error if it fails.
 }
 
-// Ensure BasicWorker implements the Worker interface.
-var _ Worker = (*BasicWorker)(nil)
+// ScheduledWorker is optionally implemented by a Worker whose first run is at an absolute time.
+// Workers that do not implement it run immediately.
+type ScheduledWorker interface {
+	RunAt() time.Time // Returns the absolute time of the first run (zero to run immediately).
+}
+
+// Ensure BasicWorker implements the Worker and ScheduledWorker interfaces.
+var (
+	_ Worker          = (*BasicWorker)(nil)
+	_ ScheduledWorker = (*BasicWorker)(nil)
+)
 
 // BasicWorker provides a basic implementation of the Worker interface.
 type BasicWorker struct {
@@ -31,6 +40,7 @@ type BasicWorker struct {
 	onRetry       func(uint, error)
 	retryAttempts uint
 	retryDelay    time.Duration
+	runAt         time.Time
 }
 
 // NewBasicWorker creates a new BasicWorker with default settings.
@@ -99,6 +109,13 @@ func (w *BasicWorker) WithRetryDelay(delay time.Duration) *BasicWorker {
 	return w
 }
 
+// WithRunAt sets the absolute time at which the worker should run first.
+// Combined with WithMaxRuns(1) it produces a one-shot worker.
+func (w *BasicWorker) WithRunAt(t time.Time) *BasicWorker {
+	w.runAt = t
+	return w
+}
+
 // Interval returns the interval at which the worker should be executed.
 func (w *BasicWorker) Interval() time.Duration {
 	return w.interval
@@ -155,3 +172,8 @@ func (w *BasicWorker) Run() error {
 
 	return nil
 }
+
+// RunAt returns the absolute time at which the worker should run first.
+func (w *BasicWorker) RunAt() time.Time {
+	return w.runAt
+}
@@ -0,0 +1,116 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of concurrent lookups performed by a batch.
+const DefaultBatchConcurrency = 8
+
+// BatchError reports the IP addresses that could not be resolved in a batch lookup.
+type BatchError struct {
+	Errors map[string]error // Errors keyed by the IP address that failed.
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	ips := make([]string, 0, len(e.Errors))
+	for ip := range e.Errors {
+		ips = append(ips, ip)
+	}
+
+	sort.Strings(ips)
+
+	items := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		items = append(items, fmt.Sprintf("%s: %v", ip, e.Errors[ip]))
+	}
+
+	return fmt.Sprintf("failed to resolve %d ips: %s", len(ips), strings.Join(items, "; "))
+}
+
+// batchResult collects the locations and per-IP errors of a batch lookup.
+type batchResult struct {
+	locations map[string]*Location
+	errs      map[string]error
+	mu        sync.Mutex
+}
+
+// newBatchResult creates a new empty batchResult.
+func newBatchResult() *batchResult {
+	return &batchResult{
+		locations: make(map[string]*Location),
+		errs:      make(map[string]error),
+	}
+}
+
+// set records the outcome of the lookup for the IP address.
+func (r *batchResult) set(ip string, location *Location, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.errs[ip] = err
+		return
+	}
+
+	r.locations[ip] = location
+}
+
+// result returns the resolved locations and a *BatchError if any lookup failed.
+func (r *batchResult) result() (map[string]*Location, error) {
+	if len(r.errs) > 0 {
+		return r.locations, &BatchError{Errors: r.errs}
+	}
+
+	return r.locations, nil
+}
+
+// getBatch resolves the IP addresses concurrently using get, with at most concurrency lookups in flight.
+// Duplicate IP addresses are resolved once, and failures are reported per IP through a *BatchError.
+func getBatch(ctx context.Context, ips []string, concurrency int, get func(context.Context, string) (*Location, error)) (map[string]*Location, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var (
+		res  = newBatchResult()
+		sem  = make(chan struct{}, concurrency)
+		seen = make(map[string]bool, len(ips))
+		wg   sync.WaitGroup
+	)
+
+	for _, ip := range ips {
+		if seen[ip] {
+			continue
+		}
+
+		seen[ip] = true
+
+		select {
+		case <-ctx.Done():
+			res.set(ip, nil, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(ip string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			location, err := get(ctx, ip)
+			res.set(ip, location, err)
+		}(ip)
+	}
+
+	wg.Wait()
+
+	return res.result()
+}
@@ -0,0 +1,192 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetBatch(t *testing.T) {
+	var (
+		inflight    atomic.Int32
+		maxInflight atomic.Int32
+		mu          sync.Mutex
+		calls       = make(map[string]int)
+	)
+
+	get := func(_ context.Context, ip string) (*Location, error) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+
+		for {
+			v := maxInflight.Load()
+			if n <= v || maxInflight.CompareAndSwap(v, n) {
+				break
+			}
+		}
+
+		mu.Lock()
+		calls[ip]++
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		if strings.HasPrefix(ip, "10.") {
+			return nil, errors.New("private address")
+		}
+
+		return &Location{IP: ip}, nil
+	}
+
+	ips := []string{"1.1.1.1", "8.8.8.8", "10.0.0.1", "1.1.1.1", "9.9.9.9", "10.0.0.2", "8.8.4.4", "8.8.8.8"}
+
+	locations, err := getBatch(context.Background(), ips, 2, get)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("getBatch() error = %v, want *BatchError", err)
+	}
+	if len(batchErr.Errors) != 2 || batchErr.Errors["10.0.0.1"] == nil || batchErr.Errors["10.0.0.2"] == nil {
+		t.Errorf("batch errors = %v, want errors for 10.0.0.1 and 10.0.0.2", batchErr.Errors)
+	}
+	if !strings.HasPrefix(err.Error(), "failed to resolve 2 ips: 10.0.0.1: ") {
+		t.Errorf("error = %q, want the failed ips in order", err)
+	}
+
+	for _, ip := range []string{"1.1.1.1", "8.8.8.8", "9.9.9.9", "8.8.4.4"} {
+		if locations[ip] == nil || locations[ip].IP != ip {
+			t.Errorf("location of %s = %v", ip, locations[ip])
+		}
+	}
+	if len(locations) != 4 {
+		t.Errorf("locations = %d, want 4", len(locations))
+	}
+
+	// Duplicate addresses are resolved once, with at most the given number of lookups in flight.
+	for ip, n := range calls {
+		if n != 1 {
+			t.Errorf("lookups of %s = %d, want 1", ip, n)
+		}
+	}
+	if got := maxInflight.Load(); got > 2 {
+		t.Errorf("lookups in flight = %d, want at most 2", got)
+	}
+}
+
+func TestGetBatchAllResolved(t *testing.T) {
+	c := newTestGeoJSClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(geojsResponse))
+	})
+
+	locations, err := c.GetBatch(context.Background(), []string{"8.8.8.8", "8.8.8.8"})
+	if err != nil {
+		t.Fatalf("GetBatch() error = %v", err)
+	}
+	if len(locations) != 1 || locations["8.8.8.8"].City != "Mountain View" {
+		t.Errorf("GetBatch() = %v, want the location of 8.8.8.8", locations)
+	}
+
+	if locations, err := c.GetBatch(context.Background(), nil); err != nil || len(locations) != 0 {
+		t.Errorf("GetBatch() of no ips = %v, %v, want no locations", locations, err)
+	}
+}
+
+func TestIPAPIClientGetBatch(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		chunks [][]string
+	)
+
+	c := newTestIPAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/batch" {
+			t.Errorf("request = %s %s, want POST /batch", r.Method, r.URL.Path)
+		}
+
+		var ips []string
+		if err := json.NewDecoder(r.Body).Decode(&ips); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		mu.Lock()
+		chunks = append(chunks, ips)
+		mu.Unlock()
+
+		// Answer in order, failing reserved addresses and leaving out the last result of a chunk ending in 9.9.9.9.
+		results := make([]map[string]interface{}, 0, len(ips))
+		for i, ip := range ips {
+			switch {
+			case strings.HasPrefix(ip, "10."):
+				results = append(results, map[string]interface{}{"status": "fail", "message": "private range", "query": ip})
+			case ip == "9.9.9.9" && i == len(ips)-1:
+			default:
+				results = append(results, map[string]interface{}{
+					"status": "success", "query": ip, "lat": 1.5, "lon": 2.5, "countryCode": "US", "as": "AS64500 Example",
+				})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(results)
+	})
+
+	// 150 unique addresses are split into requests of 100 and 50, with duplicates removed.
+	ips := make([]string, 0, 151)
+	for i := 0; i < 147; i++ {
+		ips = append(ips, fmt.Sprintf("198.51.%d.%d", i/100, i%100+1))
+	}
+	ips = append(ips, "10.0.0.1", "198.51.0.1", "8.8.8.8", "9.9.9.9")
+
+	locations, err := c.GetBatch(context.Background(), ips)
+
+	if len(chunks) != 2 || len(chunks[0]) != 100 || len(chunks[1]) != 50 {
+		t.Fatalf("requests = %d, want chunks of 100 and 50 ips", len(chunks))
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("GetBatch() error = %v, want *BatchError", err)
+	}
+	if e := batchErr.Errors["10.0.0.1"]; e == nil || !strings.Contains(e.Error(), "private range") {
+		t.Errorf("error for 10.0.0.1 = %v, want the lookup failure", e)
+	}
+	if e := batchErr.Errors["9.9.9.9"]; e == nil || !strings.Contains(e.Error(), "missing result") {
+		t.Errorf("error for 9.9.9.9 = %v, want a missing result", e)
+	}
+	if len(batchErr.Errors) != 2 {
+		t.Errorf("batch errors = %v, want 2", batchErr.Errors)
+	}
+
+	if len(locations) != 148 {
+		t.Errorf("locations = %d, want 148", len(locations))
+	}
+	if location := locations["8.8.8.8"]; location == nil || location.IP != "8.8.8.8" || location.ASN != "AS64500" {
+		t.Errorf("location of 8.8.8.8 = %v", location)
+	}
+}
+
+func TestIPAPIClientGetBatchRequestFailure(t *testing.T) {
+	c := newTestIPAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	locations, err := c.GetBatch(context.Background(), []string{"1.1.1.1", "8.8.8.8"})
+
+	// A failed request reports the failure for each of its addresses.
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("GetBatch() error = %v, want *BatchError", err)
+	}
+	for _, ip := range []string{"1.1.1.1", "8.8.8.8"} {
+		if e := batchErr.Errors[ip]; e == nil || !strings.Contains(e.Error(), "503") {
+			t.Errorf("error for %s = %v, want the response status", ip, e)
+		}
+	}
+	if len(locations) != 0 {
+		t.Errorf("locations = %d, want 0", len(locations))
+	}
+}
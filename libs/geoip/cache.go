@@ -0,0 +1,179 @@
+package geoip
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Ensure CachingClient implements the Client interface.
+var _ Client = (*CachingClient)(nil)
+
+// cacheEntry represents a cached location for a queried IP address.
+type cacheEntry struct {
+	ip        string
+	location  *Location
+	expiresAt time.Time
+}
+
+// cacheCall represents an in-flight upstream lookup shared by identical concurrent requests.
+type cacheCall struct {
+	done     chan struct{}
+	location *Location
+	err      error
+}
+
+// CachingClient wraps a Client and caches its lookups for a configurable TTL.
+// Entries are keyed by the queried IP address (an empty key for the caller's own address)
+// and evicted in least-recently-used order once the maximum number of entries is reached.
+// Identical in-flight lookups are deduplicated, and it is safe for concurrent use.
+type CachingClient struct {
+	client            Client
+	ttl               time.Duration
+	maxEntries        int
+	serveStale        bool
+	backgroundRefresh bool
+
+	entries  map[string]*list.Element
+	order    *list.List
+	inflight map[string]*cacheCall
+	mu       sync.Mutex
+}
+
+// NewCachingClient creates a new CachingClient around the given client with the specified TTL
+// and maximum number of entries (0 for unlimited).
+func NewCachingClient(client Client, ttl time.Duration, maxEntries int) *CachingClient {
+	return &CachingClient{
+		client:     client,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		inflight:   make(map[string]*cacheCall),
+	}
+}
+
+// WithServeStale sets whether expired entries are returned when the upstream lookup fails.
+func (c *CachingClient) WithServeStale(serveStale bool) *CachingClient {
+	c.serveStale = serveStale
+	return c
+}
+
+// WithBackgroundRefresh sets whether expired entries are returned immediately while being refreshed in the background.
+func (c *CachingClient) WithBackgroundRefresh(backgroundRefresh bool) *CachingClient {
+	c.backgroundRefresh = backgroundRefresh
+	return c
+}
+
+// Get retrieves location data for the specified IP address, using the cache when possible.
+// The upstream lookup is shared with identical concurrent requests and is not aborted when ctx is done,
+// but the call itself returns as soon as ctx is done.
+func (c *CachingClient) Get(ctx context.Context, ip string) (*Location, error) {
+	c.mu.Lock()
+
+	var stale *Location
+	if elem, ok := c.entries[ip]; ok {
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*cacheEntry)
+		stale = entry.location
+
+		// Return fresh entries straight from the cache.
+		if time.Now().Before(entry.expiresAt) {
+			c.mu.Unlock()
+			return entry.location, nil
+		}
+
+		// Return expired entries immediately and refresh them asynchronously.
+		if c.backgroundRefresh {
+			c.startLookup(ctx, ip)
+			c.mu.Unlock()
+
+			return entry.location, nil
+		}
+	}
+
+	call := c.startLookup(ctx, ip)
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+	}
+
+	if call.err != nil {
+		if stale != nil && c.serveStale {
+			return stale, nil
+		}
+
+		return nil, call.err
+	}
+
+	return call.location, nil
+}
+
+// startLookup returns the in-flight lookup for the IP address, starting a new one if none exists.
+// The caller must hold the mutex.
+func (c *CachingClient) startLookup(ctx context.Context, ip string) *cacheCall {
+	if call, ok := c.inflight[ip]; ok {
+		return call
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	c.inflight[ip] = call
+
+	go func() {
+		call.location, call.err = c.client.Get(context.WithoutCancel(ctx), ip)
+
+		c.mu.Lock()
+		delete(c.inflight, ip)
+		if call.err == nil {
+			c.set(ip, call.location)
+		}
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return call
+}
+
+// set stores the location for the IP address and evicts the least recently used entries if needed.
+// The caller must hold the mutex.
+func (c *CachingClient) set(ip string, location *Location) {
+	entry := &cacheEntry{
+		ip:        ip,
+		location:  location,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+
+	if elem, ok := c.entries[ip]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[ip] = c.order.PushFront(entry)
+
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).ip)
+	}
+}
+
+// Purge removes all cached entries.
+func (c *CachingClient) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// GetBatch retrieves location data for multiple IP addresses using concurrent lookups.
+func (c *CachingClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	return getBatch(ctx, ips, DefaultBatchConcurrency, c.Get)
+}
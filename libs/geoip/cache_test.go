@@ -0,0 +1,238 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// expire marks the cached entry of the IP address as expired.
+func expire(c *CachingClient, ip string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[ip].Value.(*cacheEntry).expiresAt = time.Now().Add(-time.Second)
+}
+
+func TestCachingClientTTL(t *testing.T) {
+	var requests atomic.Int32
+	upstream := newTestGeoJSClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(geojsResponse))
+	})
+
+	c := NewCachingClient(upstream, time.Hour, 0)
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(context.Background(), "8.8.8.8"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+
+	// An expired entry is looked up again.
+	expire(c, "8.8.8.8")
+	if _, err := c.Get(context.Background(), "8.8.8.8"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests after expiry = %d, want 2", got)
+	}
+
+	// Purged entries are looked up again.
+	c.Purge()
+	if _, err := c.Get(context.Background(), "8.8.8.8"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests after purge = %d, want 3", got)
+	}
+}
+
+func TestCachingClientEviction(t *testing.T) {
+	upstream := &stubClient{
+		get: func(_ context.Context, ip string) (*Location, error) { return &Location{IP: ip}, nil },
+	}
+
+	c := NewCachingClient(upstream, time.Hour, 2)
+	for _, ip := range []string{"1.1.1.1", "8.8.8.8", "1.1.1.1", "9.9.9.9"} {
+		if _, err := c.Get(context.Background(), ip); err != nil {
+			t.Fatalf("Get(%s) error = %v", ip, err)
+		}
+	}
+	if got := upstream.calls.Load(); got != 3 {
+		t.Fatalf("upstream calls = %d, want 3", got)
+	}
+
+	// The least recently used entry was evicted, while the recently used one is still cached.
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := upstream.calls.Load(); got != 3 {
+		t.Errorf("upstream calls for a cached entry = %d, want 3", got)
+	}
+
+	if _, err := c.Get(context.Background(), "8.8.8.8"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := upstream.calls.Load(); got != 4 {
+		t.Errorf("upstream calls for an evicted entry = %d, want 4", got)
+	}
+}
+
+func TestCachingClientDeduplicatesInFlight(t *testing.T) {
+	release := make(chan struct{})
+	upstream := &stubClient{
+		get: func(_ context.Context, ip string) (*Location, error) {
+			<-release
+			return &Location{IP: ip}, nil
+		},
+	}
+
+	c := NewCachingClient(upstream, time.Hour, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if location, err := c.Get(context.Background(), "8.8.8.8"); err != nil || location.IP != "8.8.8.8" {
+				t.Errorf("Get() = %v, %v", location, err)
+			}
+		}()
+	}
+
+	// Wait for the lookup to start before letting it finish.
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := upstream.calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+}
+
+func TestCachingClientServeStale(t *testing.T) {
+	for _, serveStale := range []bool{false, true} {
+		var fail atomic.Bool
+		upstream := &stubClient{
+			get: func(_ context.Context, ip string) (*Location, error) {
+				if fail.Load() {
+					return nil, errors.New("unavailable")
+				}
+
+				return &Location{IP: ip}, nil
+			},
+		}
+
+		c := NewCachingClient(upstream, time.Hour, 0).WithServeStale(serveStale)
+		if _, err := c.Get(context.Background(), "8.8.8.8"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		fail.Store(true)
+		expire(c, "8.8.8.8")
+
+		location, err := c.Get(context.Background(), "8.8.8.8")
+		if serveStale && (err != nil || location.IP != "8.8.8.8") {
+			t.Errorf("Get() with serve stale = %v, %v, want the stale location", location, err)
+		}
+		if !serveStale && err == nil {
+			t.Errorf("Get() without serve stale succeeded, want the upstream error")
+		}
+	}
+}
+
+func TestCachingClientBackgroundRefresh(t *testing.T) {
+	var (
+		city    atomic.Value
+		release = make(chan struct{})
+	)
+
+	city.Store("Sydney")
+	upstream := &stubClient{
+		get: func(_ context.Context, ip string) (*Location, error) {
+			if city.Load() != "Sydney" {
+				<-release
+			}
+
+			return &Location{IP: ip, City: city.Load().(string)}, nil
+		},
+	}
+
+	c := NewCachingClient(upstream, time.Hour, 0).WithBackgroundRefresh(true)
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// The expired entry is returned immediately while it is refreshed.
+	city.Store("Melbourne")
+	expire(c, "1.1.1.1")
+
+	location, err := c.Get(context.Background(), "1.1.1.1")
+	if err != nil || location.City != "Sydney" {
+		t.Fatalf("Get() of an expired entry = %v, %v, want Sydney", location, err)
+	}
+
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		location, err := c.Get(context.Background(), "1.1.1.1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if location.City == "Melbourne" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entry was not refreshed in the background")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCachingClientCanceled(t *testing.T) {
+	release := make(chan struct{})
+	upstream := &stubClient{
+		get: func(ctx context.Context, ip string) (*Location, error) {
+			<-release
+
+			// The shared lookup is not aborted by the caller giving up.
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			return &Location{IP: ip}, nil
+		},
+	}
+
+	c := NewCachingClient(upstream, time.Hour, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Get(ctx, "8.8.8.8"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+
+	// The lookup completes and is cached for later callers.
+	if location, err := c.Get(context.Background(), "8.8.8.8"); err != nil || location.IP != "8.8.8.8" {
+		t.Fatalf("Get() = %v, %v", location, err)
+	}
+	if got := upstream.calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+}
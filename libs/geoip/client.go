@@ -1,7 +1,10 @@
 package geoip
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -9,20 +12,70 @@ import (
 
 // Location represents geographical location information associated with an IP address.
 type Location struct {
-	City      string  `json:"city,omitempty"`      // City where the IP address is located.
-	Country   string  `json:"country,omitempty"`   // Country where the IP address is located.
-	IP        string  `json:"ip,omitempty"`        // IP address that was resolved.
-	Latitude  float64 `json:"latitude,omitempty"`  // Latitude of the location.
-	Longitude float64 `json:"longitude,omitempty"` // Longitude of the location.
+	ASN          string  `json:"asn,omitempty"`          // Autonomous system number of the network (e.g. AS15169).
+	City         string  `json:"city,omitempty"`         // City where the IP address is located.
+	Country      string  `json:"country,omitempty"`      // Country where the IP address is located.
+	CountryCode  string  `json:"country_code,omitempty"` // ISO 3166-1 alpha-2 code of the country.
+	IP           string  `json:"ip,omitempty"`           // IP address that was resolved.
+	Latitude     float64 `json:"latitude,omitempty"`     // Latitude of the location.
+	Longitude    float64 `json:"longitude,omitempty"`    // Longitude of the location.
+	Organization string  `json:"organization,omitempty"` // Organization or ISP operating the network.
+	Region       string  `json:"region,omitempty"`       // Region or state where the IP address is located.
+	Timezone     string  `json:"timezone,omitempty"`     // IANA time zone of the location.
 }
 
 func (l *Location) String() string {
 	return string(utils.MustMarshalJSON(l))
 }
 
+// ErrNullIsland is returned for locations at latitude 0 and longitude 0, which providers
+// commonly return when a lookup fails.
+var ErrNullIsland = errors.New("location is at null island (0, 0)")
+
+// Validate checks that the coordinates are within range and are not the (0, 0) default.
+func (l *Location) Validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 {
+		return fmt.Errorf("latitude %f is out of range [-90, 90]", l.Latitude)
+	}
+	if l.Longitude < -180 || l.Longitude > 180 {
+		return fmt.Errorf("longitude %f is out of range [-180, 180]", l.Longitude)
+	}
+	if l.Latitude == 0 && l.Longitude == 0 {
+		return ErrNullIsland
+	}
+
+	return nil
+}
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0
+
+// Haversine returns the great-circle distance in kilometers between the two locations.
+func Haversine(a, b *Location) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Distance returns the great-circle distance in kilometers between the two locations.
+func (l *Location) Distance(to *Location) float64 {
+	return Haversine(l, to)
+}
+
 // Client is an interface for resolving IP addresses into location data.
 type Client interface {
-	Get(ip string) (*Location, error)
+	Get(ctx context.Context, ip string) (*Location, error)                    // Resolves a single IP address (empty for the caller's own address).
+	GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) // Resolves multiple IP addresses, reporting failures per IP through a *BatchError.
+}
+
+// Lookup resolves the IP address using the given client without a cancellable context.
+//
+// Deprecated: Use Client.Get with a context instead.
+func Lookup(c Client, ip string) (*Location, error) {
+	return c.Get(context.Background(), ip)
 }
 
 // NewDefaultClient creates a new default Client instance using the default IPAPIClient.
@@ -0,0 +1,178 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// blockingHandler returns a handler that blocks until the request is abandoned by the client, or for at most
+// 10 seconds, and a channel that receives a value once a request has arrived.
+func blockingHandler() (http.HandlerFunc, <-chan struct{}) {
+	arrived := make(chan struct{}, 16)
+
+	return func(_ http.ResponseWriter, r *http.Request) {
+		// Read the body, so that the server notices when the client closes the connection.
+		_, _ = io.Copy(io.Discard, r.Body)
+		arrived <- struct{}{}
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}, arrived
+}
+
+func TestGetCanceledInFlight(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(t *testing.T, handler http.HandlerFunc) func(ctx context.Context) error
+	}{
+		{
+			name: "geojs",
+			get: func(t *testing.T, handler http.HandlerFunc) func(ctx context.Context) error {
+				c := newTestGeoJSClient(t, handler)
+				return func(ctx context.Context) error {
+					_, err := c.Get(ctx, "8.8.8.8")
+					return err
+				}
+			},
+		},
+		{
+			name: "ip_api",
+			get: func(t *testing.T, handler http.HandlerFunc) func(ctx context.Context) error {
+				c := newTestIPAPIClient(t, handler)
+				return func(ctx context.Context) error {
+					_, err := c.Get(ctx, "1.1.1.1")
+					return err
+				}
+			},
+		},
+		{
+			name: "ip_api batch",
+			get: func(t *testing.T, handler http.HandlerFunc) func(ctx context.Context) error {
+				c := newTestIPAPIClient(t, handler)
+				return func(ctx context.Context) error {
+					_, err := c.GetBatch(ctx, []string{"1.1.1.1", "8.8.8.8"})
+
+					var batchErr *BatchError
+					if errors.As(err, &batchErr) {
+						return batchErr.Errors["1.1.1.1"]
+					}
+
+					return err
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, arrived := blockingHandler()
+			get := tt.get(t, handler)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() { errCh <- get(ctx) }()
+
+			// Cancel once the request is in flight; the HTTP client timeout is far longer than the wait below.
+			select {
+			case <-arrived:
+			case <-time.After(5 * time.Second):
+				t.Fatal("request did not reach the server")
+			}
+
+			cancel()
+
+			select {
+			case err := <-errCh:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("error = %v, want %v", err, context.Canceled)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("canceled request did not return within 1s")
+			}
+		})
+	}
+}
+
+func TestGetCanceledDuringRetryWait(t *testing.T) {
+	c := newTestGeoJSClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}).WithMaxRetries(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.Get(ctx, "8.8.8.8"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Get() returned after %s, want it to stop waiting for the retry", d)
+	}
+}
+
+func TestGetBatchCanceled(t *testing.T) {
+	c := newTestGeoJSClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(geojsResponse))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Every address of a batch with a canceled context reports the cancellation.
+	locations, err := c.GetBatch(ctx, []string{"8.8.8.8", "8.8.4.4"})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("GetBatch() error = %v, want *BatchError", err)
+	}
+	if len(locations) != 0 {
+		t.Errorf("GetBatch() = %d locations, want 0", len(locations))
+	}
+	for _, ip := range []string{"8.8.8.8", "8.8.4.4"} {
+		if !errors.Is(batchErr.Errors[ip], context.Canceled) {
+			t.Errorf("error for %s = %v, want %v", ip, batchErr.Errors[ip], context.Canceled)
+		}
+	}
+}
+
+func TestGeoJSClientGet(t *testing.T) {
+	var path string
+	c := newTestGeoJSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(geojsResponse))
+	})
+
+	// The deprecated wrapper resolves the address without a cancellable context.
+	location, err := Lookup(c, "8.8.8.8")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if path != "/v1/ip/geo/8.8.8.8.json" {
+		t.Errorf("request path = %s, want /v1/ip/geo/8.8.8.8.json", path)
+	}
+
+	want := Location{
+		ASN:          "AS15169",
+		City:         "Mountain View",
+		Country:      "United States",
+		CountryCode:  "US",
+		IP:           "8.8.8.8",
+		Latitude:     37.4223,
+		Longitude:    -122.085,
+		Organization: "GOOGLE",
+		Region:       "California",
+		Timezone:     "America/Los_Angeles",
+	}
+	if *location != want {
+		t.Errorf("Lookup() = %s, want %s", location, &want)
+	}
+}
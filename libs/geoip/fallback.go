@@ -0,0 +1,160 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qubetics/qubetics-go-sdk/config"
+)
+
+const (
+	// ProviderGeoJS is the provider name of the GeoJS API.
+	ProviderGeoJS = "geojs"
+	// ProviderIPAPI is the provider name of the ip-api.com service.
+	ProviderIPAPI = "ip_api"
+	// ProviderMMDB is the provider name of a local MaxMind database.
+	ProviderMMDB = "mmdb"
+)
+
+// Ensure FallbackClient implements the Client interface.
+var _ Client = (*FallbackClient)(nil)
+
+// FallbackClient resolves IP addresses by trying an ordered list of clients until one succeeds.
+// A client that fails is skipped for a cooldown period so it isn't retried on every call.
+// A rate limited client is skipped for at least the delay reported by the provider.
+type FallbackClient struct {
+	clients   []Client
+	cooldown  time.Duration
+	mu        sync.Mutex
+	skipUntil []time.Time
+}
+
+// NewFallbackClient creates a new FallbackClient with the given clients and cooldown period.
+func NewFallbackClient(cooldown time.Duration, clients ...Client) *FallbackClient {
+	return &FallbackClient{
+		clients:   clients,
+		cooldown:  cooldown,
+		skipUntil: make([]time.Time, len(clients)),
+	}
+}
+
+// NewFallbackClientFromProviders creates a new FallbackClient from an ordered list of provider names,
+// using the specified timeout and optional proxy address for each underlying client.
+func NewFallbackClientFromProviders(providers []string, proxyAddr string, timeout, cooldown time.Duration) (*FallbackClient, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("providers cannot be empty")
+	}
+
+	clients := make([]Client, 0, len(providers))
+	for _, provider := range providers {
+		c, err := NewClientFromProvider(provider, proxyAddr, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		clients = append(clients, c)
+	}
+
+	return NewFallbackClient(cooldown, clients...), nil
+}
+
+// NewClientFromConfig creates a new Client for the provider selected in the configuration.
+func NewClientFromConfig(c *config.GeoIPConfig) (Client, error) {
+	if strings.ToLower(strings.TrimSpace(c.GetProvider())) == ProviderMMDB {
+		return NewMMDBClient(c.GetDBPath())
+	}
+
+	return NewClientFromProvider(c.GetProvider(), c.GetProxyAddr(), c.GetTimeout())
+}
+
+// NewClientFromProvider creates a new Client for the given provider name.
+func NewClientFromProvider(provider, proxyAddr string, timeout time.Duration) (Client, error) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case ProviderGeoJS:
+		return NewGeoJSClient(proxyAddr, timeout)
+	case ProviderIPAPI:
+		return NewIPAPIClient(proxyAddr, timeout)
+	default:
+		return nil, fmt.Errorf("unknown provider %s", provider)
+	}
+}
+
+// isCoolingDown reports whether the client at the given index is still in its cooldown period.
+func (c *FallbackClient) isCoolingDown(i int, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return now.Before(c.skipUntil[i])
+}
+
+// setFailed starts the cooldown period of the client at the given index, extended to the
+// retry delay of a rate limit error. A nil error clears the cooldown period.
+func (c *FallbackClient) setFailed(i int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.skipUntil[i] = time.Time{}
+		return
+	}
+
+	d := c.cooldown
+	if v, ok := RetryAfter(err); ok && v > d {
+		d = v
+	}
+
+	c.skipUntil[i] = time.Now().Add(d)
+}
+
+// Get retrieves location data for the specified IP address using the first client that succeeds.
+// Clients in their cooldown period are skipped, unless all of them are cooling down.
+func (c *FallbackClient) Get(ctx context.Context, ip string) (*Location, error) {
+	if len(c.clients) == 0 {
+		return nil, errors.New("no clients configured")
+	}
+
+	now := time.Now()
+
+	// Prefer clients that are not cooling down, then fall back to the rest in order.
+	order := make([]int, 0, len(c.clients))
+	for i := range c.clients {
+		if !c.isCoolingDown(i, now) {
+			order = append(order, i)
+		}
+	}
+	if len(order) == 0 {
+		for i := range c.clients {
+			order = append(order, i)
+		}
+	}
+
+	var errs []error
+	for _, i := range order {
+		loc, err := c.clients[i].Get(ctx, ip)
+		if err != nil {
+			// Do not penalize the client if the caller gave up on the lookup.
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			c.setFailed(i, err)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		c.setFailed(i, nil)
+		return loc, nil
+	}
+
+	return nil, fmt.Errorf("all clients failed: %w", errors.Join(errs...))
+}
+
+// GetBatch retrieves location data for multiple IP addresses using concurrent lookups.
+func (c *FallbackClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	return getBatch(ctx, ips, DefaultBatchConcurrency, c.Get)
+}
@@ -0,0 +1,261 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rewriteTransport sends every request to the host of a test server, whatever the requested URL.
+type rewriteTransport struct {
+	host string
+	rt   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "http"
+	req.URL.Host = t.host
+
+	return t.rt.RoundTrip(req)
+}
+
+// newTestHTTPClient starts a test server with the handler and returns an HTTP client that sends every
+// request to it, so that the provider clients can be tested without network access.
+func newTestHTTPClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server url: %v", err)
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &rewriteTransport{host: u.Host, rt: srv.Client().Transport},
+	}
+}
+
+// newTestGeoJSClient returns a GeoJSClient whose requests are served by the handler, without retries.
+func newTestGeoJSClient(t *testing.T, handler http.HandlerFunc) *GeoJSClient {
+	t.Helper()
+
+	c, err := NewGeoJSClient("", 0)
+	if err != nil {
+		t.Fatalf("NewGeoJSClient() error = %v", err)
+	}
+
+	c.c = newTestHTTPClient(t, handler)
+	return c.WithMaxRetries(0)
+}
+
+// newTestIPAPIClient returns an IPAPIClient whose requests are served by the handler, without retries.
+func newTestIPAPIClient(t *testing.T, handler http.HandlerFunc) *IPAPIClient {
+	t.Helper()
+
+	c, err := NewIPAPIClient("", 0)
+	if err != nil {
+		t.Fatalf("NewIPAPIClient() error = %v", err)
+	}
+
+	c.c = newTestHTTPClient(t, handler)
+	return c.WithMaxRetries(0)
+}
+
+// geojsResponse is a GeoJS response body for 8.8.8.8.
+const geojsResponse = `{"asn":15169,"city":"Mountain View","country":"United States","country_code":"US",` +
+	`"ip":"8.8.8.8","latitude":"37.4223","longitude":"-122.085","organization_name":"GOOGLE",` +
+	`"region":"California","timezone":"America/Los_Angeles"}`
+
+// ipapiResponse is an ip-api.com response body for 1.1.1.1.
+const ipapiResponse = `{"status":"success","as":"AS13335 Cloudflare, Inc.","city":"Sydney","country":"Australia",` +
+	`"countryCode":"AU","query":"1.1.1.1","isp":"Cloudflare, Inc","lat":-33.8688,"lon":151.209,` +
+	`"org":"APNIC and Cloudflare DNS Resolver project","regionName":"New South Wales","timezone":"Australia/Sydney"}`
+
+// stubClient is a Client that resolves addresses with a function and counts its calls.
+type stubClient struct {
+	calls atomic.Int32
+	get   func(ctx context.Context, ip string) (*Location, error)
+}
+
+// Get implements the Client interface.
+func (c *stubClient) Get(ctx context.Context, ip string) (*Location, error) {
+	c.calls.Add(1)
+	return c.get(ctx, ip)
+}
+
+// GetBatch implements the Client interface.
+func (c *stubClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	return getBatch(ctx, ips, DefaultBatchConcurrency, c.Get)
+}
+
+// newStubClient returns a stubClient that always returns the location and error.
+func newStubClient(location *Location, err error) *stubClient {
+	return &stubClient{
+		get: func(context.Context, string) (*Location, error) { return location, err },
+	}
+}
+
+func TestFallbackClientProviderOrder(t *testing.T) {
+	failing := newTestGeoJSClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	working := newTestIPAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(ipapiResponse))
+	})
+
+	c := NewFallbackClient(time.Minute, failing, working)
+
+	location, err := c.Get(context.Background(), "1.1.1.1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if location.IP != "1.1.1.1" || location.CountryCode != "AU" {
+		t.Errorf("Get() = %s, want the ip-api.com location", location)
+	}
+}
+
+func TestFallbackClientCooldown(t *testing.T) {
+	first := newStubClient(nil, errors.New("unavailable"))
+	second := newStubClient(&Location{IP: "1.1.1.1"}, nil)
+
+	c := NewFallbackClient(time.Hour, first, second)
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+
+	// The failing client is skipped during its cooldown period.
+	if got := first.calls.Load(); got != 1 {
+		t.Errorf("failing client calls = %d, want 1", got)
+	}
+	if got := second.calls.Load(); got != 3 {
+		t.Errorf("working client calls = %d, want 3", got)
+	}
+
+	// The client is tried again once its cooldown period is over.
+	c.skipUntil[0] = time.Now().Add(-time.Second)
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := first.calls.Load(); got != 2 {
+		t.Errorf("failing client calls after cooldown = %d, want 2", got)
+	}
+}
+
+func TestFallbackClientAllCoolingDown(t *testing.T) {
+	calls := 0
+	first := &stubClient{
+		get: func(context.Context, string) (*Location, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("unavailable")
+			}
+
+			return &Location{IP: "1.1.1.1"}, nil
+		},
+	}
+	second := newStubClient(nil, errors.New("unavailable"))
+
+	c := NewFallbackClient(time.Hour, first, second)
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err == nil || !strings.Contains(err.Error(), "all clients failed") {
+		t.Fatalf("Get() error = %v, want all clients failed", err)
+	}
+
+	// With every client cooling down, all of them are tried in order.
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := second.calls.Load(); got != 1 {
+		t.Errorf("second client calls = %d, want 1", got)
+	}
+
+	// A successful lookup clears the cooldown period.
+	if !c.skipUntil[0].IsZero() {
+		t.Errorf("cooldown of a recovered client was not cleared")
+	}
+}
+
+func TestFallbackClientRateLimitCooldown(t *testing.T) {
+	limited := newTestIPAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	c := NewFallbackClient(time.Second, limited, newStubClient(&Location{IP: "1.1.1.1"}, nil))
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// The cooldown period is extended to the retry delay reported by the provider.
+	if d := time.Until(c.skipUntil[0]); d < 59*time.Minute {
+		t.Errorf("cooldown = %s, want at least the retry delay of 1h", d)
+	}
+}
+
+func TestFallbackClientCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	first := &stubClient{
+		get: func(context.Context, string) (*Location, error) {
+			cancel()
+			return nil, context.Canceled
+		},
+	}
+	second := newStubClient(&Location{IP: "1.1.1.1"}, nil)
+
+	c := NewFallbackClient(time.Hour, first, second)
+	if _, err := c.Get(ctx, "1.1.1.1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() error = %v, want %v", err, context.Canceled)
+	}
+
+	// The lookup stops without trying the next client or penalizing the current one.
+	if got := second.calls.Load(); got != 0 {
+		t.Errorf("second client calls = %d, want 0", got)
+	}
+	if !c.skipUntil[0].IsZero() {
+		t.Errorf("client was put in cooldown after the caller gave up")
+	}
+}
+
+func TestNewFallbackClientFromProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []string
+		wantErr   bool
+	}{
+		{name: "single", providers: []string{"geojs"}},
+		{name: "ordered", providers: []string{" IP_API ", "geojs"}},
+		{name: "empty", providers: nil, wantErr: true},
+		{name: "unknown", providers: []string{"geojs", "maxmind"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFallbackClientFromProviders(tt.providers, "", time.Second, time.Minute)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewFallbackClientFromProviders() succeeded, want error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFallbackClientFromProviders() error = %v", err)
+			}
+			if len(c.clients) != len(tt.providers) {
+				t.Errorf("clients = %d, want %d", len(c.clients), len(tt.providers))
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package geoip
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,7 +15,8 @@ var _ Client = (*GeoJSClient)(nil)
 
 // GeoJSClient is a client for retrieving location data using the GeoJS API.
 type GeoJSClient struct {
-	c *http.Client
+	c          *http.Client
+	maxRetries uint
 }
 
 // NewGeoJSClient creates and returns a new instance of GeoJSClient with the specified timeout and optional proxy address.
@@ -36,11 +38,18 @@ func NewGeoJSClient(proxyAddr string, timeout time.Duration) (*GeoJSClient, erro
 			Timeout:   timeout,
 			Transport: transport,
 		},
+		maxRetries: DefaultMaxRetries,
 	}, nil
 }
 
+// WithMaxRetries sets the number of retries for requests throttled by the GeoJS API.
+func (c *GeoJSClient) WithMaxRetries(retries uint) *GeoJSClient {
+	c.maxRetries = retries
+	return c
+}
+
 // Get retrieves location data for the specified IP address using the GeoJS API.
-func (c *GeoJSClient) Get(ip string) (*Location, error) {
+func (c *GeoJSClient) Get(ctx context.Context, ip string) (*Location, error) {
 	// Construct the URL for the API request. Use the provided IP address if it is not empty.
 	apiURL := "https://get.geojs.io/v1/ip/geo.json"
 	if ip != "" {
@@ -48,7 +57,8 @@ func (c *GeoJSClient) Get(ip string) (*Location, error) {
 	}
 
 	// Make the HTTP GET request to the GeoJS API.
-	resp, err := c.c.Get(apiURL)
+	// Rate limited requests are retried; ErrRateLimited is returned once retries are exhausted.
+	resp, err := doWithRetry(ctx, c.c, http.MethodGet, apiURL, nil, c.maxRetries)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +71,16 @@ func (c *GeoJSClient) Get(ip string) (*Location, error) {
 
 	// Parse the JSON response into a temporary structure.
 	var result struct {
-		City      string `json:"city"`
-		Country   string `json:"country"`
-		IP        string `json:"ip"`
-		Latitude  string `json:"latitude"`
-		Longitude string `json:"longitude"`
+		ASN              json.Number `json:"asn"`
+		City             string      `json:"city"`
+		Country          string      `json:"country"`
+		CountryCode      string      `json:"country_code"`
+		IP               string      `json:"ip"`
+		Latitude         string      `json:"latitude"`
+		Longitude        string      `json:"longitude"`
+		OrganizationName string      `json:"organization_name"`
+		Region           string      `json:"region"`
+		Timezone         string      `json:"timezone"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -82,12 +97,33 @@ func (c *GeoJSClient) Get(ip string) (*Location, error) {
 		return nil, err
 	}
 
-	// Return the location information as a Location struct.
-	return &Location{
-		City:      result.City,
-		Country:   result.Country,
-		IP:        result.IP,
-		Latitude:  latitude,
-		Longitude: longitude,
-	}, nil
+	// Format the ASN with the conventional "AS" prefix if it is available.
+	asn := ""
+	if result.ASN != "" {
+		asn = "AS" + result.ASN.String()
+	}
+
+	// Build the location information, rejecting invalid coordinates.
+	location := &Location{
+		ASN:          asn,
+		City:         result.City,
+		Country:      result.Country,
+		CountryCode:  result.CountryCode,
+		IP:           result.IP,
+		Latitude:     latitude,
+		Longitude:    longitude,
+		Organization: result.OrganizationName,
+		Region:       result.Region,
+		Timezone:     result.Timezone,
+	}
+	if err := location.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	return location, nil
+}
+
+// GetBatch retrieves location data for multiple IP addresses using concurrent lookups.
+func (c *GeoJSClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	return getBatch(ctx, ips, DefaultBatchConcurrency, c.Get)
 }
@@ -0,0 +1,169 @@
+package geoip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a provider keeps throttling requests after all retries are exhausted.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is returned when a provider throttles requests. It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	RetryAfter time.Duration // Time to wait before the provider accepts requests again, zero if unknown.
+	Status     string        // Status of the throttled response, empty if the request was not sent.
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	msg := ErrRateLimited.Error()
+	if e.Status != "" {
+		msg += ": status " + e.Status
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+
+	return msg
+}
+
+// Is reports whether the target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RetryAfter returns the time to wait before retrying if the error is a *RateLimitError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var e *RateLimitError
+	if !errors.As(err, &e) {
+		return 0, false
+	}
+
+	return e.RetryAfter, true
+}
+
+// rateWindow tracks the time until which a provider is known to reject requests.
+type rateWindow struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// check returns a *RateLimitError if the provider is still within its rate limit window.
+func (w *rateWindow) check() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if d := time.Until(w.until); d > 0 {
+		return &RateLimitError{RetryAfter: d}
+	}
+
+	return nil
+}
+
+// extend blocks requests for at least the given duration.
+func (w *rateWindow) extend(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(w.until) {
+		w.until = until
+	}
+}
+
+// update blocks requests until the window resets when ip-api.com reports that no requests
+// remain in the current window through the X-Rl and X-Ttl headers.
+func (w *rateWindow) update(header http.Header) {
+	if header.Get("X-Rl") != "0" {
+		return
+	}
+
+	if secs, err := strconv.Atoi(header.Get("X-Ttl")); err == nil && secs > 0 {
+		w.extend(time.Duration(secs) * time.Second)
+	}
+}
+
+// observe extends the window if the error reports a rate limit with a known retry delay.
+func (w *rateWindow) observe(err error) {
+	if d, ok := RetryAfter(err); ok && d > 0 {
+		w.extend(d)
+	}
+}
+
+const (
+	// DefaultMaxRetries is the default number of retries for throttled requests.
+	DefaultMaxRetries = 1
+
+	retryBaseDelay = 1 * time.Second  // Base delay of the exponential backoff when no retry window is given.
+	retryMaxDelay  = 30 * time.Second // Maximum delay to wait before retrying; longer windows are not waited for.
+)
+
+// doWithRetry performs an HTTP request with an optional JSON body, retrying throttled responses up to maxRetries times.
+// Retry-After and ip-api.com's X-Ttl headers are honored when present, otherwise exponential backoff is used.
+func doWithRetry(ctx context.Context, c *http.Client, method, apiURL string, body []byte, maxRetries uint) (*http.Response, error) {
+	for attempt := uint(0); ; attempt++ {
+		var reqBody io.Reader = http.NoBody
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		_ = resp.Body.Close()
+
+		// Give up if retries are exhausted or the provider asks to wait too long.
+		delay := retryDelay(resp.Header, attempt)
+		if attempt >= maxRetries || delay > retryMaxDelay {
+			return nil, &RateLimitError{RetryAfter: delay, Status: resp.Status}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns the delay before the next attempt based on the response headers and attempt number.
+func retryDelay(header http.Header, attempt uint) time.Duration {
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t)
+		}
+	}
+
+	// ip-api.com reports the seconds until the rate limit window resets in X-Ttl.
+	if v := header.Get("X-Ttl"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	return retryBaseDelay << attempt
+}
@@ -1,10 +1,13 @@
 package geoip
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,7 +16,9 @@ var _ Client = (*IPAPIClient)(nil)
 
 // IPAPIClient is a client for retrieving location data using the ip-api.com service.
 type IPAPIClient struct {
-	c *http.Client
+	c          *http.Client
+	maxRetries uint
+	window     rateWindow
 }
 
 // NewIPAPIClient creates and returns a new instance of IPAPIClient with the specified timeout and optional proxy address.
@@ -35,16 +40,41 @@ func NewIPAPIClient(proxyAddr string, timeout time.Duration) (*IPAPIClient, erro
 			Timeout:   timeout,
 			Transport: transport,
 		},
+		maxRetries: DefaultMaxRetries,
 	}, nil
 }
 
+// WithMaxRetries sets the number of retries for requests throttled by the ip-api.com service.
+func (c *IPAPIClient) WithMaxRetries(retries uint) *IPAPIClient {
+	c.maxRetries = retries
+	return c
+}
+
+// do performs a request to the ip-api.com service, failing fast with a *RateLimitError while the
+// rate limit window reported by the service has not reset.
+func (c *IPAPIClient) do(ctx context.Context, method, apiURL string, body []byte) (*http.Response, error) {
+	if err := c.window.check(); err != nil {
+		return nil, err
+	}
+
+	resp, err := doWithRetry(ctx, c.c, method, apiURL, body, c.maxRetries)
+	if err != nil {
+		c.window.observe(err)
+		return nil, err
+	}
+
+	c.window.update(resp.Header)
+	return resp, nil
+}
+
 // Get retrieves location data for the specified IP address using the ip-api.com service.
-func (c *IPAPIClient) Get(ip string) (*Location, error) {
+func (c *IPAPIClient) Get(ctx context.Context, ip string) (*Location, error) {
 	// Construct the URL for the API request using the provided IP address.
 	apiURL := fmt.Sprintf("http://ip-api.com/json/%s", ip)
 
 	// Make the HTTP GET request to the ip-api.com service.
-	resp, err := c.c.Get(apiURL)
+	// Rate limited requests are retried; a *RateLimitError is returned once retries are exhausted.
+	resp, err := c.do(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -56,24 +86,137 @@ func (c *IPAPIClient) Get(ip string) (*Location, error) {
 	}
 
 	// Parse the JSON response into a temporary structure.
-	var result struct {
-		City      string  `json:"city"`
-		Country   string  `json:"country"`
-		IP        string  `json:"query"` // Note: IP field is named "query" in ip-api.com response.
-		Latitude  float64 `json:"lat"`
-		Longitude float64 `json:"lon"`
+	var result ipapiResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	// Return the location information as a Location struct, rejecting invalid coordinates.
+	location := result.Location()
+	if err := location.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	return location, nil
+}
+
+// ipapiBatchSize is the maximum number of IP addresses accepted by a single ip-api.com batch request.
+const ipapiBatchSize = 100
+
+// GetBatch retrieves location data for multiple IP addresses using the ip-api.com batch endpoint,
+// splitting the addresses into requests of at most 100 entries.
+func (c *IPAPIClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	res := newBatchResult()
+
+	// Deduplicate the IP addresses while preserving their order.
+	seen := make(map[string]bool, len(ips))
+	unique := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if !seen[ip] {
+			seen[ip] = true
+			unique = append(unique, ip)
+		}
+	}
+
+	for start := 0; start < len(unique); start += ipapiBatchSize {
+		end := min(start+ipapiBatchSize, len(unique))
+		chunk := unique[start:end]
+
+		results, err := c.getBatch(ctx, chunk)
+		if err != nil {
+			for _, ip := range chunk {
+				res.set(ip, nil, err)
+			}
+
+			continue
+		}
+
+		// The batch endpoint returns results in the same order as the queried addresses.
+		for i, ip := range chunk {
+			if i >= len(results) {
+				res.set(ip, nil, errors.New("missing result"))
+				continue
+			}
+			if results[i].Status != "" && results[i].Status != "success" {
+				res.set(ip, nil, fmt.Errorf("lookup failed: %s", results[i].Message))
+				continue
+			}
+
+			location := results[i].Location()
+			if err := location.Validate(); err != nil {
+				res.set(ip, nil, fmt.Errorf("invalid location: %w", err))
+				continue
+			}
+
+			res.set(ip, location, nil)
+		}
+	}
+
+	return res.result()
+}
+
+// getBatch performs a single ip-api.com batch request for the given IP addresses.
+func (c *IPAPIClient) getBatch(ctx context.Context, ips []string) ([]ipapiResult, error) {
+	body, err := json.Marshal(ips)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP POST request to the ip-api.com batch endpoint.
+	resp, err := c.do(ctx, http.MethodPost, "http://ip-api.com/batch", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check if the response status code indicates success.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve data, status: %s", resp.Status)
+	}
+
+	var results []ipapiResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		return nil, err
 	}
 
-	// Return the location information as a Location struct.
+	return results, nil
+}
+
+// ipapiResult represents a single lookup result returned by the ip-api.com service.
+type ipapiResult struct {
+	AS          string  `json:"as"` // Note: AS field contains the number followed by the name, e.g. "AS15169 Google LLC".
+	City        string  `json:"city"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"countryCode"`
+	IP          string  `json:"query"` // Note: IP field is named "query" in ip-api.com response.
+	ISP         string  `json:"isp"`
+	Latitude    float64 `json:"lat"`
+	Longitude   float64 `json:"lon"`
+	Message     string  `json:"message"`
+	Org         string  `json:"org"`
+	RegionName  string  `json:"regionName"`
+	Status      string  `json:"status"`
+	Timezone    string  `json:"timezone"`
+}
+
+// Location converts the result into a Location.
+func (r *ipapiResult) Location() *Location {
+	// Use the ISP as the organization if the organization is not available.
+	organization := r.Org
+	if organization == "" {
+		organization = r.ISP
+	}
+
 	return &Location{
-		City:      result.City,
-		Country:   result.Country,
-		IP:        result.IP,
-		Latitude:  result.Latitude,
-		Longitude: result.Longitude,
-	}, nil
+		ASN:          strings.SplitN(r.AS, " ", 2)[0],
+		City:         r.City,
+		Country:      r.Country,
+		CountryCode:  r.CountryCode,
+		IP:           r.IP,
+		Latitude:     r.Latitude,
+		Longitude:    r.Longitude,
+		Organization: organization,
+		Region:       r.RegionName,
+		Timezone:     r.Timezone,
+	}
 }
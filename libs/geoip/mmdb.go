@@ -0,0 +1,186 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Ensure MMDBClient implements the Client interface.
+var _ Client = (*MMDBClient)(nil)
+
+// mmdbReloadCheckInterval is the minimum interval between checks for a replaced database file.
+const mmdbReloadCheckInterval = 5 * time.Second
+
+// mmdbRecord represents the subset of a GeoLite2-City record used to build a Location.
+type mmdbRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  float64 `maxminddb:"latitude"`
+		Longitude float64 `maxminddb:"longitude"`
+		TimeZone  string  `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+	Subdivisions []struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+}
+
+// MMDBClient is a client for retrieving location data from a local MaxMind GeoLite2-City database.
+// The database is reopened automatically when the file is replaced on disk.
+type MMDBClient struct {
+	path      string
+	reader    *maxminddb.Reader
+	modTime   time.Time
+	checkedAt time.Time
+	mu        sync.RWMutex
+}
+
+// NewMMDBClient creates and returns a new instance of MMDBClient backed by the database file at the specified path.
+func NewMMDBClient(path string) (*MMDBClient, error) {
+	c := &MMDBClient{path: path}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reload reopens the database file and replaces the current reader.
+func (c *MMDBClient) Reload() error {
+	stat, err := os.Stat(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat database file: %w", err)
+	}
+
+	reader, err := maxminddb.Open(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to open database file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev := c.reader
+	c.reader = reader
+	c.modTime = stat.ModTime()
+	c.checkedAt = time.Now()
+
+	if prev != nil {
+		return prev.Close()
+	}
+
+	return nil
+}
+
+// Close releases the underlying database reader.
+func (c *MMDBClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.reader == nil {
+		return nil
+	}
+
+	err := c.reader.Close()
+	c.reader = nil
+
+	return err
+}
+
+// reloadIfModified reopens the database if the file has been modified since it was last loaded.
+// The check is performed at most once per mmdbReloadCheckInterval.
+func (c *MMDBClient) reloadIfModified() error {
+	c.mu.Lock()
+	if time.Since(c.checkedAt) < mmdbReloadCheckInterval {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.checkedAt = time.Now()
+	modTime := c.modTime
+	c.mu.Unlock()
+
+	stat, err := os.Stat(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat database file: %w", err)
+	}
+	if stat.ModTime().Equal(modTime) {
+		return nil
+	}
+
+	return c.Reload()
+}
+
+// Get retrieves location data for the specified IP address from the local database.
+// An IP address is required, since the public address of the caller cannot be resolved locally.
+func (c *MMDBClient) Get(ctx context.Context, ip string) (*Location, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ip == "" {
+		return nil, errors.New("ip cannot be empty")
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, fmt.Errorf("invalid ip %s", ip)
+	}
+
+	if err := c.reloadIfModified(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.reader == nil {
+		return nil, errors.New("database is closed")
+	}
+
+	// Look up the record for the IP address in the database.
+	var record mmdbRecord
+	if err := c.reader.Lookup(addr, &record); err != nil {
+		return nil, err
+	}
+
+	// Use the most significant subdivision as the region, if any.
+	region := ""
+	if len(record.Subdivisions) > 0 {
+		region = record.Subdivisions[0].Names["en"]
+	}
+
+	// Build the location information, rejecting invalid coordinates.
+	// The ASN and organization are not part of the City database and are left empty.
+	location := &Location{
+		City:        record.City.Names["en"],
+		Country:     record.Country.Names["en"],
+		CountryCode: record.Country.ISOCode,
+		IP:          addr.String(),
+		Latitude:    record.Location.Latitude,
+		Longitude:   record.Location.Longitude,
+		Region:      region,
+		Timezone:    record.Location.TimeZone,
+	}
+	if err := location.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	return location, nil
+}
+
+// GetBatch retrieves location data for multiple IP addresses using concurrent lookups.
+func (c *MMDBClient) GetBatch(ctx context.Context, ips []string) (map[string]*Location, error) {
+	return getBatch(ctx, ips, DefaultBatchConcurrency, c.Get)
+}
@@ -0,0 +1,308 @@
+package geoip
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mmdbControl returns the control bytes of a MaxMind DB data field with the given type and size.
+func mmdbControl(typ, size int) []byte {
+	var buf []byte
+	switch {
+	case size < 29:
+		buf = []byte{byte(size)}
+	case size < 285:
+		buf = []byte{29, byte(size - 29)}
+	default:
+		buf = []byte{30, byte((size - 285) >> 8), byte(size - 285)}
+	}
+
+	if typ <= 7 {
+		buf[0] |= byte(typ << 5)
+		return buf
+	}
+
+	// Extended types are stored in the byte following the control byte, before the size bytes.
+	return append([]byte{buf[0], byte(typ - 7)}, buf[1:]...)
+}
+
+// mmdbUint encodes an unsigned integer of the given type with the minimum number of bytes.
+func mmdbUint(typ int, v uint64) []byte {
+	var buf []byte
+	for ; v > 0; v >>= 8 {
+		buf = append([]byte{byte(v)}, buf...)
+	}
+
+	return append(mmdbControl(typ, len(buf)), buf...)
+}
+
+// mmdbEncode encodes a value as a MaxMind DB data field.
+func mmdbEncode(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	switch v := v.(type) {
+	case string:
+		return append(mmdbControl(2, len(v)), v...)
+	case float64:
+		return binary.BigEndian.AppendUint64(mmdbControl(3, 8), math.Float64bits(v))
+	case uint16:
+		return mmdbUint(5, uint64(v))
+	case uint32:
+		return mmdbUint(6, uint64(v))
+	case uint64:
+		return mmdbUint(9, v)
+	case map[string]interface{}:
+		buf := mmdbControl(7, len(v))
+		for key, item := range v {
+			buf = append(buf, mmdbEncode(t, key)...)
+			buf = append(buf, mmdbEncode(t, item)...)
+		}
+
+		return buf
+	case []interface{}:
+		buf := mmdbControl(11, len(v))
+		for _, item := range v {
+			buf = append(buf, mmdbEncode(t, item)...)
+		}
+
+		return buf
+	default:
+		t.Fatalf("unsupported mmdb type %T", v)
+		return nil
+	}
+}
+
+// writeTestMMDB writes an IPv4 MaxMind DB file with 24-bit records, mapping each of the non-overlapping
+// networks to its record.
+func writeTestMMDB(t *testing.T, path string, networks map[string]map[string]interface{}) {
+	t.Helper()
+
+	// Build the search tree as a binary trie. A record holds the index of a child node, noRecord,
+	// or the offset of its data in the data section encoded as -(offset+2).
+	const noRecord = -1
+
+	var (
+		nodes = [][2]int{{noRecord, noRecord}}
+		data  []byte
+	)
+
+	for cidr, record := range networks {
+		prefix := netip.MustParsePrefix(cidr)
+		addr := prefix.Addr().As4()
+
+		offset := len(data)
+		data = append(data, mmdbEncode(t, record)...)
+
+		node := 0
+		for i := 0; i < prefix.Bits(); i++ {
+			bit := addr[i/8] >> (7 - i%8) & 1
+			if i == prefix.Bits()-1 {
+				nodes[node][bit] = -(offset + 2)
+				break
+			}
+			if nodes[node][bit] < 0 {
+				nodes = append(nodes, [2]int{noRecord, noRecord})
+				nodes[node][bit] = len(nodes) - 1
+			}
+
+			node = nodes[node][bit]
+		}
+	}
+
+	// Records pointing to data hold the node count plus the separator size plus the data offset.
+	count := len(nodes)
+	value := func(record int) int {
+		switch {
+		case record >= 0:
+			return record
+		case record == noRecord:
+			return count
+		default:
+			return count + 16 + (-record - 2)
+		}
+	}
+
+	var buf []byte
+	for _, node := range nodes {
+		for _, record := range node {
+			v := value(record)
+			buf = append(buf, byte(v>>16), byte(v>>8), byte(v))
+		}
+	}
+
+	buf = append(buf, make([]byte, 16)...)
+	buf = append(buf, data...)
+	buf = append(buf, "\xAB\xCD\xEFMaxMind.com"...)
+	buf = append(buf, mmdbEncode(t, map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(time.Now().Unix()),
+		"database_type":               "GeoLite2-City",
+		"description":                 map[string]interface{}{"en": "test database"},
+		"ip_version":                  uint16(4),
+		"languages":                   []interface{}{"en"},
+		"node_count":                  uint32(count),
+		"record_size":                 uint16(24),
+	})...)
+
+	// Replace the file atomically, as database updates are usually deployed.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0644); err != nil {
+		t.Fatalf("failed to write database: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("failed to rename database: %v", err)
+	}
+}
+
+// mmdbCityRecord returns a GeoLite2-City record with the given names and coordinates.
+func mmdbCityRecord(city, countryCode, country, region string, latitude, longitude float64, timezone string) map[string]interface{} {
+	return map[string]interface{}{
+		"city": map[string]interface{}{
+			"names": map[string]interface{}{"en": city},
+		},
+		"country": map[string]interface{}{
+			"iso_code": countryCode,
+			"names":    map[string]interface{}{"en": country},
+		},
+		"location": map[string]interface{}{
+			"latitude":  latitude,
+			"longitude": longitude,
+			"time_zone": timezone,
+		},
+		"subdivisions": []interface{}{
+			map[string]interface{}{"names": map[string]interface{}{"en": region}},
+		},
+	}
+}
+
+func TestMMDBClientGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+	writeTestMMDB(t, path, map[string]map[string]interface{}{
+		"1.1.1.0/24":  mmdbCityRecord("Sydney", "AU", "Australia", "New South Wales", -33.8688, 151.209, "Australia/Sydney"),
+		"8.8.8.0/24":  mmdbCityRecord("Mountain View", "US", "United States", "California", 37.4223, -122.085, "America/Los_Angeles"),
+		"10.0.0.0/31": mmdbCityRecord("", "", "", "", 0, 0, ""),
+	})
+
+	c, err := NewMMDBClient(path)
+	if err != nil {
+		t.Fatalf("NewMMDBClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	location, err := c.Get(context.Background(), "1.1.1.1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	want := Location{
+		City:        "Sydney",
+		Country:     "Australia",
+		CountryCode: "AU",
+		IP:          "1.1.1.1",
+		Latitude:    -33.8688,
+		Longitude:   151.209,
+		Region:      "New South Wales",
+		Timezone:    "Australia/Sydney",
+	}
+	if *location != want {
+		t.Errorf("Get() = %s, want %s", location, &want)
+	}
+
+	if location, err := c.Get(context.Background(), "8.8.8.8"); err != nil || location.City != "Mountain View" {
+		t.Errorf("Get(8.8.8.8) = %v, %v, want Mountain View", location, err)
+	}
+
+	tests := []struct {
+		name    string
+		ip      string
+		wantErr string
+	}{
+		{name: "not in database", ip: "9.9.9.9", wantErr: "invalid location"},
+		{name: "null island record", ip: "10.0.0.1", wantErr: "invalid location"},
+		{name: "empty ip", ip: "", wantErr: "ip cannot be empty"},
+		{name: "invalid ip", ip: "1.1.1", wantErr: "invalid ip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Get(context.Background(), tt.ip); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Get(%q) error = %v, want error containing %q", tt.ip, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMMDBClientReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+	writeTestMMDB(t, path, map[string]map[string]interface{}{
+		"1.1.1.0/24": mmdbCityRecord("Sydney", "AU", "Australia", "New South Wales", -33.8688, 151.209, "Australia/Sydney"),
+	})
+
+	c, err := NewMMDBClient(path)
+	if err != nil {
+		t.Fatalf("NewMMDBClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	// Replace the database with a newer modification time.
+	writeTestMMDB(t, path, map[string]map[string]interface{}{
+		"1.1.1.0/24": mmdbCityRecord("Melbourne", "AU", "Australia", "Victoria", -37.8136, 144.9631, "Australia/Melbourne"),
+	})
+
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("failed to change modification time: %v", err)
+	}
+
+	// The file is not checked again within the check interval.
+	if location, err := c.Get(context.Background(), "1.1.1.1"); err != nil || location.City != "Sydney" {
+		t.Errorf("Get() within the check interval = %v, %v, want Sydney", location, err)
+	}
+
+	// The replaced file is loaded once the check interval has passed.
+	c.mu.Lock()
+	c.checkedAt = time.Now().Add(-mmdbReloadCheckInterval)
+	c.mu.Unlock()
+
+	if location, err := c.Get(context.Background(), "1.1.1.1"); err != nil || location.City != "Melbourne" {
+		t.Errorf("Get() after the database was replaced = %v, %v, want Melbourne", location, err)
+	}
+}
+
+func TestMMDBClientClosedAndCanceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+	writeTestMMDB(t, path, map[string]map[string]interface{}{
+		"1.1.1.0/24": mmdbCityRecord("Sydney", "AU", "Australia", "New South Wales", -33.8688, 151.209, "Australia/Sydney"),
+	})
+
+	c, err := NewMMDBClient(path)
+	if err != nil {
+		t.Fatalf("NewMMDBClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Get(ctx, "1.1.1.1"); err != context.Canceled {
+		t.Errorf("Get() with a canceled context error = %v, want %v", err, context.Canceled)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := c.Get(context.Background(), "1.1.1.1"); err == nil || !strings.Contains(err.Error(), "database is closed") {
+		t.Errorf("Get() after Close() error = %v, want database is closed", err)
+	}
+
+	if _, err := NewMMDBClient(filepath.Join(t.TempDir(), "missing.mmdb")); err == nil {
+		t.Errorf("NewMMDBClient() with a missing file succeeded")
+	}
+}
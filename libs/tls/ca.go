@@ -0,0 +1,241 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qubetics/qubetics-go-sdk/utils"
+)
+
+// CA is a local certificate authority used to issue server certificates for a fleet of nodes,
+// so that clients can pin a single root certificate.
+type CA struct {
+	Cert         *x509.Certificate
+	CertPath     string
+	Curve        elliptic.Curve
+	Key          crypto.Signer
+	KeyPath      string
+	KeyType      string
+	Organization string
+	RSABits      int
+	Validity     int
+}
+
+// NewCA creates a new CA with default values.
+func NewCA() *CA {
+	return &CA{
+		Curve:        elliptic.P256(),
+		KeyType:      KeyTypeECDSA,
+		Organization: "Sentinel",
+		RSABits:      MinRSABits,
+		Validity:     3650,
+	}
+}
+
+// WithCertPath sets the CA certificate path.
+func (ca *CA) WithCertPath(certPath string) *CA {
+	ca.CertPath = certPath
+	return ca
+}
+
+// WithCurve sets the elliptic curve for the CA key.
+func (ca *CA) WithCurve(curve elliptic.Curve) *CA {
+	ca.Curve = curve
+	return ca
+}
+
+// WithKeyPath sets the CA key path.
+func (ca *CA) WithKeyPath(keyPath string) *CA {
+	ca.KeyPath = keyPath
+	return ca
+}
+
+// WithKeyType sets the type of the CA private key (ecdsa, ed25519 or rsa).
+func (ca *CA) WithKeyType(keyType string) *CA {
+	ca.KeyType = keyType
+	return ca
+}
+
+// WithOrganization sets the organization name.
+func (ca *CA) WithOrganization(organization string) *CA {
+	ca.Organization = organization
+	return ca
+}
+
+// WithRSABits sets the RSA key size in bits, used when the key type is rsa.
+func (ca *CA) WithRSABits(bits int) *CA {
+	ca.RSABits = bits
+	return ca
+}
+
+// WithValidity sets the validity duration for the CA certificate in days.
+func (ca *CA) WithValidity(days int) *CA {
+	ca.Validity = days
+	return ca
+}
+
+// LoadCA reads the CA certificate and private key from the specified paths.
+func LoadCA(certPath, keyPath string) (*CA, error) {
+	cert, err := readCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+	if !cert.IsCA {
+		return nil, errors.New("certificate is not a ca")
+	}
+
+	key, err := readPrivateKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if !keyMatches(key, cert.PublicKey) {
+		return nil, errors.New("private key does not match certificate")
+	}
+
+	return &CA{
+		Cert:     cert,
+		CertPath: certPath,
+		Key:      key,
+		KeyPath:  keyPath,
+	}, nil
+}
+
+// Validate validates the key settings of the CA.
+func (ca *CA) Validate() error {
+	return validateKeyParams(ca.KeyType, ca.Curve, ca.RSABits)
+}
+
+// Generate creates the CA private key and self-signed CA certificate and writes them to the specified paths.
+func (ca *CA) Generate() error {
+	if err := ca.Validate(); err != nil {
+		return fmt.Errorf("invalid ca: %w", err)
+	}
+
+	// Generate private key
+	key, err := generatePrivateKey(ca.KeyType, ca.Curve, ca.RSABits)
+	if err != nil {
+		return fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	// Create a random serial number for the certificate
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return err
+	}
+
+	// Define certificate validity period
+	notBefore := time.Now()
+	notAfter := notBefore.AddDate(0, 0, ca.Validity)
+
+	// Define CA certificate template
+	tmpl := &x509.Certificate{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		MaxPathLenZero:        true,
+		NotAfter:              notAfter,
+		NotBefore:             notBefore,
+		SerialNumber:          serialNumber,
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s CA", ca.Organization),
+			Organization: []string{ca.Organization},
+		},
+	}
+
+	// Generate the self-signed CA certificate
+	certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	// Marshal the private key
+	blockType, keyBytes, err := marshalPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	// Write the private key and certificate to file
+	if err := utils.WritePEMFile(ca.KeyPath, blockType, keyBytes); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := utils.WritePEMFile(ca.CertPath, "CERTIFICATE", certBytes); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+
+	ca.Cert = cert
+	ca.Key = key
+
+	return nil
+}
+
+// SignLeaf issues a server certificate for the public key and addresses (IP or DNS), valid for the given number of days.
+// It returns the DER encoded certificate.
+func (ca *CA) SignLeaf(pub crypto.PublicKey, addrs []string, organization string, validity int) ([]byte, error) {
+	// Define certificate template
+	tmpl, err := newLeafTemplate(pub, addrs, organization, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	return ca.sign(tmpl, pub)
+}
+
+// sign signs the leaf certificate template with the CA key and returns the DER encoded certificate.
+func (ca *CA) sign(tmpl *x509.Certificate, pub crypto.PublicKey) ([]byte, error) {
+	if ca.Cert == nil || ca.Key == nil {
+		return nil, errors.New("ca is not loaded")
+	}
+
+	// The leaf certificate must not outlive the CA
+	if tmpl.NotAfter.After(ca.Cert.NotAfter) {
+		tmpl.NotAfter = ca.Cert.NotAfter
+	}
+
+	// Sign the certificate with the CA key
+	certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, pub, ca.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return certBytes, nil
+}
+
+// SignCSR issues a server certificate for a certificate signing request, valid for the given number of days.
+// The subject alternative names of the request are validated and preserved. It returns the DER encoded certificate.
+func (ca *CA) SignCSR(csr *x509.CertificateRequest, validity int) ([]byte, error) {
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid csr signature: %w", err)
+	}
+
+	addrs := append([]string{}, csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		addrs = append(addrs, ip.String())
+	}
+
+	var organization string
+	if len(csr.Subject.Organization) > 0 {
+		organization = csr.Subject.Organization[0]
+	}
+
+	// Define certificate template, preserving the email and URI names of the request
+	tmpl, err := newLeafTemplate(csr.PublicKey, addrs, organization, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.EmailAddresses = csr.EmailAddresses
+	tmpl.URIs = csr.URIs
+
+	return ca.sign(tmpl, csr.PublicKey)
+}
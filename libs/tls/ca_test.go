@@ -0,0 +1,146 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+// newTestCA generates a CA in a temporary directory.
+func newTestCA(t *testing.T) *CA {
+	t.Helper()
+
+	dir := t.TempDir()
+	ca := NewCA().
+		WithCertPath(filepath.Join(dir, "ca.crt")).
+		WithKeyPath(filepath.Join(dir, "ca.key"))
+
+	if err := ca.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	return ca
+}
+
+// verifyLeaf verifies the DER encoded leaf certificate against the CA root for the given name.
+func verifyLeaf(t *testing.T, ca *CA, der []byte, name string) *x509.Certificate {
+	t.Helper()
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse leaf: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: roots}); err != nil {
+		t.Errorf("Verify(%q) error = %v", name, err)
+	}
+
+	return leaf
+}
+
+func TestCASignLeaf(t *testing.T) {
+	ca := newTestCA(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	der, err := ca.SignLeaf(key.Public(), []string{"node.example.com", "203.0.113.7"}, "Node", 30)
+	if err != nil {
+		t.Fatalf("SignLeaf() error = %v", err)
+	}
+
+	verifyLeaf(t, ca, der, "node.example.com")
+	verifyLeaf(t, ca, der, "203.0.113.7")
+}
+
+func TestCASignCSR(t *testing.T) {
+	ca := newTestCA(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	buf, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		DNSNames:       []string{"node.example.com"},
+		EmailAddresses: []string{"ops@example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("2001:db8::7")},
+		Subject:        pkix.Name{Organization: []string{"Node"}},
+	}, key)
+	if err != nil {
+		t.Fatalf("failed to create csr: %v", err)
+	}
+
+	csr, err := x509.ParseCertificateRequest(buf)
+	if err != nil {
+		t.Fatalf("failed to parse csr: %v", err)
+	}
+
+	der, err := ca.SignCSR(csr, 30)
+	if err != nil {
+		t.Fatalf("SignCSR() error = %v", err)
+	}
+
+	leaf := verifyLeaf(t, ca, der, "node.example.com")
+	verifyLeaf(t, ca, der, "2001:db8::7")
+
+	if len(leaf.EmailAddresses) != 1 || leaf.EmailAddresses[0] != "ops@example.com" {
+		t.Errorf("EmailAddresses = %v, want [ops@example.com]", leaf.EmailAddresses)
+	}
+}
+
+func TestCASignLeafDoesNotOutliveCA(t *testing.T) {
+	ca := newTestCA(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	der, err := ca.SignLeaf(key.Public(), []string{"node.example.com"}, "Node", ca.Validity+365)
+	if err != nil {
+		t.Fatalf("SignLeaf() error = %v", err)
+	}
+
+	leaf := verifyLeaf(t, ca, der, "node.example.com")
+	if leaf.NotAfter.After(ca.Cert.NotAfter) {
+		t.Errorf("leaf NotAfter %s is after ca NotAfter %s", leaf.NotAfter, ca.Cert.NotAfter)
+	}
+}
+
+func TestCAGenerateInvalidKey(t *testing.T) {
+	tests := []struct {
+		name string
+		ca   *CA
+	}{
+		{name: "unknown key type", ca: NewCA().WithKeyType("dsa")},
+		{name: "small rsa key", ca: NewCA().WithKeyType(KeyTypeRSA).WithRSABits(1024)},
+		{name: "nil curve", ca: NewCA().WithCurve(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			ca := tt.ca.
+				WithCertPath(filepath.Join(dir, "ca.crt")).
+				WithKeyPath(filepath.Join(dir, "ca.key"))
+
+			if err := ca.Generate(); err == nil {
+				t.Fatal("Generate() error = nil, want error")
+			}
+			if ca.Cert != nil {
+				t.Errorf("Generate() set the certificate")
+			}
+		})
+	}
+}
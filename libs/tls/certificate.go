@@ -1,14 +1,19 @@
 package tls
 
 import (
-	"crypto/ecdsa"
+	"bytes"
+	"crypto"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
+	"os"
 	"time"
 
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -16,19 +21,37 @@ import (
 
 type Certificate struct {
 	Addrs        []string
+	CA           *CA
 	CertPath     string
 	Curve        elliptic.Curve
+	Emails       []string
 	KeyPath      string
+	KeyType      string
 	Organization string
+	RenewBefore  time.Duration
+	RSABits      int
+	URIs         []string
 	Validity     int
 }
 
+// CertificateInfo holds the parsed metadata of a certificate loaded from disk.
+type CertificateInfo struct {
+	DNSNames    []string  // DNS subject alternative names.
+	Fingerprint string    // SHA-256 fingerprint of the DER certificate in colon-separated hex form.
+	IPAddresses []net.IP  // IP subject alternative names.
+	NotAfter    time.Time // Time after which the certificate is no longer valid.
+	NotBefore   time.Time // Time before which the certificate is not yet valid.
+}
+
 // NewCertificate creates a new Certificate with default values.
 func NewCertificate() *Certificate {
 	return &Certificate{
 		Addrs:        []string{"127.0.0.1", "localhost"},
 		Curve:        elliptic.P256(),
+		KeyType:      KeyTypeECDSA,
 		Organization: "Sentinel",
+		RenewBefore:  30 * 24 * time.Hour,
+		RSABits:      MinRSABits,
 		Validity:     365,
 	}
 }
@@ -39,6 +62,12 @@ func (c *Certificate) WithAddrs(addrs []string) *Certificate {
 	return c
 }
 
+// WithCA sets the CA used to sign the certificate instead of self-signing it.
+func (c *Certificate) WithCA(ca *CA) *Certificate {
+	c.CA = ca
+	return c
+}
+
 // WithCertPath sets the certificate path.
 func (c *Certificate) WithCertPath(certPath string) *Certificate {
 	c.CertPath = certPath
@@ -51,6 +80,24 @@ func (c *Certificate) WithCurve(curve elliptic.Curve) *Certificate {
 	return c
 }
 
+// WithEmails sets the email address subject alternative names for the certificate.
+func (c *Certificate) WithEmails(emails []string) *Certificate {
+	c.Emails = emails
+	return c
+}
+
+// WithKeyType sets the type of the private key (ecdsa, ed25519 or rsa).
+func (c *Certificate) WithKeyType(keyType string) *Certificate {
+	c.KeyType = keyType
+	return c
+}
+
+// WithRSABits sets the RSA key size in bits, used when the key type is rsa.
+func (c *Certificate) WithRSABits(bits int) *Certificate {
+	c.RSABits = bits
+	return c
+}
+
 // WithKeyPath sets the key path.
 func (c *Certificate) WithKeyPath(keyPath string) *Certificate {
 	c.KeyPath = keyPath
@@ -63,76 +110,278 @@ func (c *Certificate) WithOrganization(organization string) *Certificate {
 	return c
 }
 
+// WithRenewBefore sets the window before expiry in which EnsureValid reissues the certificate.
+func (c *Certificate) WithRenewBefore(d time.Duration) *Certificate {
+	c.RenewBefore = d
+	return c
+}
+
+// WithURIs sets the URI subject alternative names for the certificate.
+func (c *Certificate) WithURIs(uris []string) *Certificate {
+	c.URIs = uris
+	return c
+}
+
 // WithValidity sets the validity duration for the certificate in days.
 func (c *Certificate) WithValidity(days int) *Certificate {
 	c.Validity = days
 	return c
 }
 
+// LoadCertificate reads the certificate and private key from the specified paths, verifies that
+// they form a valid key pair and returns the parsed certificate metadata.
+func LoadCertificate(certPath, keyPath string) (*CertificateInfo, error) {
+	cert, err := readCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the private key matches the certificate.
+	key, err := readPrivateKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if !keyMatches(key, cert.PublicKey) {
+		return nil, errors.New("private key does not match certificate")
+	}
+
+	return &CertificateInfo{
+		DNSNames:    cert.DNSNames,
+		Fingerprint: formatFingerprint(cert.Raw),
+		IPAddresses: cert.IPAddresses,
+		NotAfter:    cert.NotAfter,
+		NotBefore:   cert.NotBefore,
+	}, nil
+}
+
+// readCertificate reads and parses the first PEM encoded certificate from the file.
+func readCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode certificate pem block")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// IsExpiringWithin reports whether the certificate at CertPath expires within the given duration.
+func (c *Certificate) IsExpiringWithin(d time.Duration) (bool, error) {
+	cert, err := readCertificate(c.CertPath)
+	if err != nil {
+		return false, err
+	}
+
+	return time.Now().Add(d).After(cert.NotAfter), nil
+}
+
+// coversAddrs reports whether the certificate at CertPath is valid for every address of Addrs.
+func (c *Certificate) coversAddrs() (bool, error) {
+	cert, err := readCertificate(c.CertPath)
+	if err != nil {
+		return false, err
+	}
+
+	for _, addr := range c.Addrs {
+		if err := cert.VerifyHostname(addr); err != nil {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// EnsureValid generates the certificate if it is missing, invalid, expiring within RenewBefore or not
+// valid for every address of Addrs. When only the certificate needs to be reissued, the existing private
+// key is preserved.
+func (c *Certificate) EnsureValid() error {
+	if _, err := LoadCertificate(c.CertPath, c.KeyPath); err == nil {
+		expiring, err := c.IsExpiringWithin(c.RenewBefore)
+		if err != nil {
+			return err
+		}
+
+		covered, err := c.coversAddrs()
+		if err != nil {
+			return err
+		}
+		if !expiring && covered {
+			return nil
+		}
+	}
+
+	// Reuse the existing private key if it can be read, otherwise generate a new one.
+	if key, err := readPrivateKey(c.KeyPath); err == nil {
+		return c.issue(key)
+	}
+
+	return c.Generate()
+}
+
+// Validate validates the key settings and subject alternative names of the certificate.
+func (c *Certificate) Validate() error {
+	if _, _, err := parseAddrs(c.Addrs); err != nil {
+		return err
+	}
+	if _, err := parseEmails(c.Emails); err != nil {
+		return err
+	}
+	if _, err := parseURIs(c.URIs); err != nil {
+		return err
+	}
+
+	return validateKeyParams(c.KeyType, c.Curve, c.RSABits)
+}
+
 // Generate creates and writes the certificate and private key to the specified paths.
 func (c *Certificate) Generate() error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+
 	// Generate private key
-	pk, err := ecdsa.GenerateKey(c.Curve, rand.Reader)
+	pk, err := generatePrivateKey(c.KeyType, c.Curve, c.RSABits)
 	if err != nil {
 		return fmt.Errorf("failed to generate private key: %w", err)
 	}
 
-	// Create a random serial number for the certificate
-	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	// Marshal the private key
+	blockType, keyBytes, err := marshalPrivateKey(pk)
 	if err != nil {
-		return fmt.Errorf("failed to generate serial number: %w", err)
+		return fmt.Errorf("failed to marshal private key: %w", err)
 	}
 
-	// Separate addresses into domain names and IP addresses
-	var domainNames []string
-	var ipAddrs []net.IP
-	for _, item := range c.Addrs {
-		if ip := net.ParseIP(item); ip != nil {
-			ipAddrs = append(ipAddrs, ip)
-		} else {
-			domainNames = append(domainNames, item)
+	// Write the private key to file
+	if err := utils.WritePEMFile(c.KeyPath, blockType, keyBytes); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+
+	return c.issue(pk)
+}
+
+// issue creates a certificate for the private key and writes it to CertPath.
+// The certificate is signed by the CA if one is set, otherwise it is self-signed.
+func (c *Certificate) issue(pk crypto.Signer) error {
+	// Define certificate template
+	tmpl, err := c.template(pk.Public())
+	if err != nil {
+		return err
+	}
+
+	var chain [][]byte
+	if c.CA != nil {
+		// Sign the leaf certificate with the CA and append the CA certificate to the chain
+		certBytes, err := c.CA.sign(tmpl, pk.Public())
+		if err != nil {
+			return fmt.Errorf("failed to sign certificate: %w", err)
+		}
+
+		chain = [][]byte{certBytes, c.CA.Cert.Raw}
+	} else {
+		// Generate the self-signed certificate
+		certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, pk.Public(), pk)
+		if err != nil {
+			return fmt.Errorf("failed to create certificate: %w", err)
 		}
+
+		chain = [][]byte{certBytes}
+	}
+
+	// Write the certificate chain to file
+	if err := WriteChainPEMFile(c.CertPath, chain...); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+
+	return nil
+}
+
+// template creates the leaf certificate template for the public key, including all subject alternative names.
+func (c *Certificate) template(pub crypto.PublicKey) (*x509.Certificate, error) {
+	tmpl, err := newLeafTemplate(pub, c.Addrs, c.Organization, c.Validity)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.EmailAddresses, err = parseEmails(c.Emails); err != nil {
+		return nil, err
+	}
+	if tmpl.URIs, err = parseURIs(c.URIs); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// newSerialNumber generates a random 128-bit certificate serial number.
+func newSerialNumber() (*big.Int, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	return serialNumber, nil
+}
+
+// newLeafTemplate creates a server certificate template for the public key, addresses and validity in days.
+func newLeafTemplate(pub crypto.PublicKey, addrs []string, organization string, validity int) (*x509.Certificate, error) {
+	// Create a random serial number for the certificate
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate and separate addresses into domain names and IP addresses
+	domainNames, ipAddrs, err := parseAddrs(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Key encipherment only applies to RSA keys
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, ok := pub.(*rsa.PublicKey); ok {
+		keyUsage |= x509.KeyUsageKeyEncipherment
 	}
 
 	// Define certificate validity period
 	notBefore := time.Now()
-	notAfter := notBefore.AddDate(0, 0, c.Validity)
+	notAfter := notBefore.AddDate(0, 0, validity)
 
-	// Define certificate template
-	cert := x509.Certificate{
+	return &x509.Certificate{
 		BasicConstraintsValid: true,
 		DNSNames:              domainNames,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		IPAddresses:           ipAddrs,
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              keyUsage,
 		NotAfter:              notAfter,
 		NotBefore:             notBefore,
 		SerialNumber:          serialNumber,
 		Subject: pkix.Name{
-			Organization: []string{c.Organization},
+			Organization: []string{organization},
 		},
-	}
-
-	// Generate the self-signed certificate
-	certBytes, err := x509.CreateCertificate(rand.Reader, &cert, &cert, &pk.PublicKey, pk)
-	if err != nil {
-		return fmt.Errorf("failed to create certificate: %w", err)
-	}
-
-	// Write the certificate to file
-	if err := utils.WritePEMFile(c.CertPath, "CERTIFICATE", certBytes); err != nil {
-		return fmt.Errorf("failed to write certificate: %w", err)
-	}
+	}, nil
+}
 
-	// Marshal the private key
-	keyBytes, err := x509.MarshalECPrivateKey(pk)
-	if err != nil {
-		return fmt.Errorf("failed to marshal private key: %w", err)
+// WriteChainPEMFile writes the DER encoded certificates to the file as consecutive PEM blocks,
+// starting with the leaf certificate.
+func WriteChainPEMFile(path string, certs ...[]byte) error {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert}); err != nil {
+			return fmt.Errorf("failed to encode pem block: %w", err)
+		}
 	}
 
-	// Write the private key to file
-	if err := utils.WritePEMFile(c.KeyPath, "EC PRIVATE KEY", keyBytes); err != nil {
-		return fmt.Errorf("failed to write private key: %w", err)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,182 @@
+package tls
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestCertificate returns a certificate in a temporary directory, valid for the given number of days.
+func newTestCertificate(t *testing.T, validity int) *Certificate {
+	t.Helper()
+
+	dir := t.TempDir()
+	return NewCertificate().
+		WithCertPath(filepath.Join(dir, "tls.crt")).
+		WithKeyPath(filepath.Join(dir, "tls.key")).
+		WithValidity(validity)
+}
+
+// fingerprints returns the fingerprint of the certificate and the content of the private key.
+func fingerprints(t *testing.T, c *Certificate) (string, string) {
+	t.Helper()
+
+	info, err := LoadCertificate(c.CertPath, c.KeyPath)
+	if err != nil {
+		t.Fatalf("LoadCertificate() error = %v", err)
+	}
+
+	key, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		t.Fatalf("failed to read key: %v", err)
+	}
+
+	return info.Fingerprint, string(key)
+}
+
+func TestCertificateEnsureValidRenewalBoundary(t *testing.T) {
+	// Margins of a few hours keep the cases clear of daylight saving time shifts, since the validity
+	// is added in calendar days.
+	tests := []struct {
+		name        string
+		validity    int
+		renewBefore time.Duration
+		wantRenewal bool
+	}{
+		{name: "far from expiry", validity: 365, renewBefore: 30 * 24 * time.Hour, wantRenewal: false},
+		{name: "just outside window", validity: 30, renewBefore: 30*24*time.Hour - 3*time.Hour, wantRenewal: false},
+		{name: "just inside window", validity: 30, renewBefore: 30*24*time.Hour + 3*time.Hour, wantRenewal: true},
+		{name: "within window", validity: 10, renewBefore: 30 * 24 * time.Hour, wantRenewal: true},
+		{name: "no renewal window", validity: 1, renewBefore: 0, wantRenewal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCertificate(t, tt.validity).WithRenewBefore(tt.renewBefore)
+			if err := c.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			oldCert, oldKey := fingerprints(t, c)
+
+			// Renewed certificates are valid for a year, so a renewal moves the expiry out of the window.
+			c.WithValidity(365)
+			if err := c.EnsureValid(); err != nil {
+				t.Fatalf("EnsureValid() error = %v", err)
+			}
+
+			newCert, newKey := fingerprints(t, c)
+			if renewed := newCert != oldCert; renewed != tt.wantRenewal {
+				t.Errorf("renewed = %t, want %t", renewed, tt.wantRenewal)
+			}
+			if newKey != oldKey {
+				t.Errorf("EnsureValid() replaced the private key")
+			}
+
+			expiring, err := c.IsExpiringWithin(tt.renewBefore)
+			if err != nil {
+				t.Fatalf("IsExpiringWithin() error = %v", err)
+			}
+			if expiring {
+				t.Errorf("certificate still expires within %s after EnsureValid()", tt.renewBefore)
+			}
+		})
+	}
+}
+
+func TestCertificateEnsureValidAddrs(t *testing.T) {
+	c := newTestCertificate(t, 365)
+	if err := c.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	oldCert, oldKey := fingerprints(t, c)
+
+	// A certificate that does not cover a configured address is reissued with the same key.
+	c.WithAddrs([]string{"203.0.113.7", "node.example.com"})
+	if err := c.EnsureValid(); err != nil {
+		t.Fatalf("EnsureValid() error = %v", err)
+	}
+
+	newCert, newKey := fingerprints(t, c)
+	if newCert == oldCert {
+		t.Errorf("EnsureValid() did not reissue the certificate for new addrs")
+	}
+	if newKey != oldKey {
+		t.Errorf("EnsureValid() replaced the private key")
+	}
+
+	info, err := LoadCertificate(c.CertPath, c.KeyPath)
+	if err != nil {
+		t.Fatalf("LoadCertificate() error = %v", err)
+	}
+	if len(info.DNSNames) != 1 || info.DNSNames[0] != "node.example.com" {
+		t.Errorf("DNSNames = %v, want [node.example.com]", info.DNSNames)
+	}
+	if len(info.IPAddresses) != 1 || info.IPAddresses[0].String() != "203.0.113.7" {
+		t.Errorf("IPAddresses = %v, want [203.0.113.7]", info.IPAddresses)
+	}
+
+	// A certificate covering the addresses is kept.
+	if err := c.EnsureValid(); err != nil {
+		t.Fatalf("EnsureValid() error = %v", err)
+	}
+	if cert, _ := fingerprints(t, c); cert != newCert {
+		t.Errorf("EnsureValid() reissued a valid certificate")
+	}
+}
+
+func TestCertificateEnsureValidMissing(t *testing.T) {
+	c := newTestCertificate(t, 365)
+
+	// Both files are generated when missing.
+	if err := c.EnsureValid(); err != nil {
+		t.Fatalf("EnsureValid() error = %v", err)
+	}
+
+	_, oldKey := fingerprints(t, c)
+
+	// A missing certificate is reissued for the existing key.
+	if err := os.Remove(c.CertPath); err != nil {
+		t.Fatalf("failed to remove certificate: %v", err)
+	}
+	if err := c.EnsureValid(); err != nil {
+		t.Fatalf("EnsureValid() error = %v", err)
+	}
+	if _, newKey := fingerprints(t, c); newKey != oldKey {
+		t.Errorf("EnsureValid() replaced the private key")
+	}
+
+	// A missing key is generated along with a new certificate.
+	if err := os.Remove(c.KeyPath); err != nil {
+		t.Fatalf("failed to remove key: %v", err)
+	}
+	if err := c.EnsureValid(); err != nil {
+		t.Fatalf("EnsureValid() error = %v", err)
+	}
+	if _, newKey := fingerprints(t, c); newKey == oldKey {
+		t.Errorf("EnsureValid() did not generate a new private key")
+	}
+}
+
+func TestLoadCertificateFingerprint(t *testing.T) {
+	c := newTestCertificate(t, 30)
+	if err := c.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	data, err := os.ReadFile(c.CertPath)
+	if err != nil {
+		t.Fatalf("failed to read certificate: %v", err)
+	}
+
+	want, err := FingerprintSHA256(data)
+	if err != nil {
+		t.Fatalf("FingerprintSHA256() error = %v", err)
+	}
+
+	if got, _ := fingerprints(t, c); got != want {
+		t.Errorf("LoadCertificate() fingerprint = %s, want %s", got, want)
+	}
+}
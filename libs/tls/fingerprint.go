@@ -0,0 +1,119 @@
+package tls
+
+import (
+	"context"
+	"crypto/sha256"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Fingerprints holds the SHA-256 fingerprints of a certificate in colon-separated hex form.
+type Fingerprints struct {
+	Certificate string // Fingerprint of the full DER encoded certificate.
+	SPKI        string // Fingerprint of the DER encoded subject public key info.
+}
+
+// newFingerprints computes the fingerprints of the parsed certificate.
+func newFingerprints(cert *x509.Certificate) *Fingerprints {
+	return &Fingerprints{
+		Certificate: formatFingerprint(cert.Raw),
+		SPKI:        formatFingerprint(cert.RawSubjectPublicKeyInfo),
+	}
+}
+
+// formatFingerprint returns the SHA-256 digest of the data as upper-case hex bytes separated by colons.
+func formatFingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = strings.ToUpper(hex.EncodeToString([]byte{b}))
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// parseCertificate parses the first certificate from PEM or DER encoded data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected pem block type %s", block.Type)
+		}
+
+		data = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// FingerprintSHA256 returns the SHA-256 fingerprint of a PEM or DER encoded certificate in colon-separated hex form.
+func FingerprintSHA256(data []byte) (string, error) {
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return "", err
+	}
+
+	return formatFingerprint(cert.Raw), nil
+}
+
+// Fingerprints returns the certificate and SPKI fingerprints of the leaf certificate at CertPath.
+func (c *Certificate) Fingerprints() (*Fingerprints, error) {
+	cert, err := readCertificate(c.CertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFingerprints(cert), nil
+}
+
+// FetchFingerprints connects to the address (host:port) and returns the fingerprints of the presented
+// certificate chain, starting with the leaf. The chain is not verified, so that the fingerprints can be
+// compared out-of-band before pinning.
+func FetchFingerprints(ctx context.Context, addr string, timeout time.Duration) ([]*Fingerprints, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Perform the TLS handshake without verifying the presented chain
+	dialer := &cryptotls.Dialer{
+		Config: &cryptotls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         host,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	defer conn.Close()
+
+	certs := conn.(*cryptotls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates presented")
+	}
+
+	res := make([]*Fingerprints, 0, len(certs))
+	for _, cert := range certs {
+		res = append(res, newFingerprints(cert))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// KeyTypeECDSA is the key type for ECDSA private keys.
+	KeyTypeECDSA = "ecdsa"
+	// KeyTypeEd25519 is the key type for Ed25519 private keys.
+	KeyTypeEd25519 = "ed25519"
+	// KeyTypeRSA is the key type for RSA private keys.
+	KeyTypeRSA = "rsa"
+
+	// MinRSABits is the minimum accepted RSA key size in bits.
+	MinRSABits = 2048
+)
+
+// validateKeyParams checks that a private key of the given type can be generated with the curve or RSA key size.
+func validateKeyParams(keyType string, curve elliptic.Curve, rsaBits int) error {
+	switch keyType {
+	case KeyTypeECDSA:
+		if curve == nil {
+			return errors.New("curve cannot be nil")
+		}
+	case KeyTypeEd25519:
+	case KeyTypeRSA:
+		if rsaBits < MinRSABits {
+			return fmt.Errorf("rsa bits must be at least %d", MinRSABits)
+		}
+	default:
+		return fmt.Errorf("invalid key type %s", keyType)
+	}
+
+	return nil
+}
+
+// generatePrivateKey generates a new private key of the given type.
+func generatePrivateKey(keyType string, curve elliptic.Curve, rsaBits int) (crypto.Signer, error) {
+	switch keyType {
+	case KeyTypeECDSA:
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case KeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	case KeyTypeRSA:
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keyType)
+	}
+}
+
+// marshalPrivateKey encodes the private key and returns its DER bytes along with the matching PEM block type.
+// Keys without a dedicated encoding, such as Ed25519, are marshaled as PKCS#8.
+func marshalPrivateKey(key crypto.Signer) (string, []byte, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		buf, err := x509.MarshalECPrivateKey(k)
+		return "EC PRIVATE KEY", buf, err
+	case *rsa.PrivateKey:
+		return "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(k), nil
+	default:
+		buf, err := x509.MarshalPKCS8PrivateKey(k)
+		return "PRIVATE KEY", buf, err
+	}
+}
+
+// parsePrivateKey decodes a private key from the DER bytes of the given PEM block type.
+func parsePrivateKey(blockType string, data []byte) (crypto.Signer, error) {
+	switch blockType {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(data)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(data)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported pem block type %s", blockType)
+	}
+}
+
+// readPrivateKey reads and parses the PEM encoded private key from the file.
+func readPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem block")
+	}
+
+	key, err := parsePrivateKey(block.Type, block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return key, nil
+}
+
+// keyMatches reports whether the private key corresponds to the given public key.
+func keyMatches(key crypto.Signer, pub crypto.PublicKey) bool {
+	k, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(pub)
+}
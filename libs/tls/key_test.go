@@ -0,0 +1,77 @@
+package tls
+
+import (
+	"crypto/ed25519"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestEd25519CertificateServesTLS(t *testing.T) {
+	dir := t.TempDir()
+	cert := NewCertificate().
+		WithCertPath(filepath.Join(dir, "tls.crt")).
+		WithKeyPath(filepath.Join(dir, "tls.key")).
+		WithKeyType(KeyTypeEd25519)
+
+	if err := cert.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	pair, err := cryptotls.LoadX509KeyPair(cert.CertPath, cert.KeyPath)
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair() error = %v", err)
+	}
+
+	// Serve a single TLS connection with the loaded key pair.
+	listener, err := cryptotls.Listen("tcp", "127.0.0.1:0", &cryptotls.Config{
+		Certificates: []cryptotls.Certificate{pair},
+		MinVersion:   cryptotls.VersionTLS13,
+	})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = conn.Write([]byte("ok"))
+	}()
+
+	// Trust the self-signed certificate and complete a handshake against the server.
+	leaf, err := readCertificate(cert.CertPath)
+	if err != nil {
+		t.Fatalf("readCertificate() error = %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	conn, err := cryptotls.Dial("tcp", listener.Addr().String(), &cryptotls.Config{
+		RootCAs:    roots,
+		ServerName: "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	buf, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if string(buf) != "ok" {
+		t.Errorf("read %q, want %q", buf, "ok")
+	}
+
+	if _, ok := conn.ConnectionState().PeerCertificates[0].PublicKey.(ed25519.PublicKey); !ok {
+		t.Errorf("peer certificate key is %T, want ed25519.PublicKey", conn.ConnectionState().PeerCertificates[0].PublicKey)
+	}
+}
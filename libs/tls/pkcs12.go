@@ -0,0 +1,74 @@
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// readCertificateChain reads and parses all PEM encoded certificates from the file, starting with the leaf.
+func readCertificateChain(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("failed to decode certificate pem block")
+	}
+
+	return chain, nil
+}
+
+// ExportPKCS12 bundles the private key, the leaf certificate and any CA certificates from CertPath
+// into a password protected PKCS#12 file at the given path. The certificate must have been generated first.
+func (c *Certificate) ExportPKCS12(path, password string) error {
+	// Read the certificate chain and the private key written by Generate
+	chain, err := readCertificateChain(c.CertPath)
+	if err != nil {
+		return err
+	}
+
+	key, err := readPrivateKey(c.KeyPath)
+	if err != nil {
+		return err
+	}
+	if !keyMatches(key, chain[0].PublicKey) {
+		return errors.New("private key does not match certificate")
+	}
+
+	// Encode the bundle using modern encryption algorithms
+	data, err := pkcs12.Modern.Encode(key, chain[0], chain[1:], password)
+	if err != nil {
+		return fmt.Errorf("failed to encode pkcs12 bundle: %w", err)
+	}
+
+	// Write the bundle with restricted permissions since it contains the private key
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pkcs12 bundle: %w", err)
+	}
+
+	return nil
+}
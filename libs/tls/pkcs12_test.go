@@ -0,0 +1,69 @@
+package tls
+
+import (
+	"crypto"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestExportPKCS12RoundTrip(t *testing.T) {
+	ca := newTestCA(t)
+
+	dir := t.TempDir()
+	cert := NewCertificate().
+		WithCA(ca).
+		WithCertPath(filepath.Join(dir, "tls.crt")).
+		WithKeyPath(filepath.Join(dir, "tls.key"))
+
+	if err := cert.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	path := filepath.Join(dir, "tls.p12")
+	if err := cert.ExportPKCS12(path, "secret"); err != nil {
+		t.Fatalf("ExportPKCS12() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat bundle: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("bundle permissions = %o, want 600", perm)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read bundle: %v", err)
+	}
+
+	key, leaf, caCerts, err := pkcs12.DecodeChain(data, "secret")
+	if err != nil {
+		t.Fatalf("DecodeChain() error = %v", err)
+	}
+
+	// The bundle holds the certificate, key and CA certificate written to disk.
+	want, err := readCertificate(cert.CertPath)
+	if err != nil {
+		t.Fatalf("readCertificate() error = %v", err)
+	}
+	if leaf.SerialNumber.Cmp(want.SerialNumber) != 0 {
+		t.Errorf("leaf serial = %s, want %s", leaf.SerialNumber, want.SerialNumber)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok || !keyMatches(signer, want.PublicKey) {
+		t.Errorf("bundle key does not match the certificate")
+	}
+
+	if len(caCerts) != 1 || caCerts[0].SerialNumber.Cmp(ca.Cert.SerialNumber) != 0 {
+		t.Errorf("bundle ca certificates do not match the ca")
+	}
+
+	if _, _, _, err := pkcs12.DecodeChain(data, "wrong"); err == nil {
+		t.Errorf("DecodeChain() with a wrong password succeeded")
+	}
+}
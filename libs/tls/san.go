@@ -0,0 +1,173 @@
+package tls
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// maxHostnameLength is the maximum length of a DNS name in presentation format.
+const maxHostnameLength = 253
+
+// parseAddrs validates the addresses and separates them into DNS names and IP addresses.
+// IPv6 literals may be enclosed in brackets. The returned error lists every invalid entry.
+func parseAddrs(addrs []string) (dnsNames []string, ipAddrs []net.IP, err error) {
+	var invalid []string
+	for _, item := range addrs {
+		// IPv6 literals in URL form are enclosed in brackets
+		if strings.HasPrefix(item, "[") || strings.HasSuffix(item, "]") {
+			addr, err := parseBracketedIPv6(item)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%q (%s)", item, err))
+				continue
+			}
+
+			ipAddrs = append(ipAddrs, addr.AsSlice())
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(item); err == nil {
+			if addr.Zone() != "" {
+				invalid = append(invalid, fmt.Sprintf("%q (ip address must not have a zone)", item))
+				continue
+			}
+
+			ipAddrs = append(ipAddrs, addr.Unmap().AsSlice())
+			continue
+		}
+
+		if err := validateHostname(item); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q (%s)", item, err))
+			continue
+		}
+
+		dnsNames = append(dnsNames, strings.ToLower(item))
+	}
+
+	if len(invalid) > 0 {
+		return nil, nil, fmt.Errorf("invalid addrs: %s", strings.Join(invalid, ", "))
+	}
+
+	return dnsNames, ipAddrs, nil
+}
+
+// parseBracketedIPv6 parses an IPv6 literal enclosed in brackets, such as [::1].
+func parseBracketedIPv6(s string) (netip.Addr, error) {
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return netip.Addr{}, errors.New("unbalanced brackets")
+	}
+
+	addr, err := netip.ParseAddr(s[1 : len(s)-1])
+	if err != nil || !addr.Is6() || addr.Is4In6() {
+		return netip.Addr{}, errors.New("brackets must enclose an ipv6 address")
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, errors.New("ip address must not have a zone")
+	}
+
+	return addr, nil
+}
+
+// validateHostname checks that the name is a valid hostname per RFC 1123. Internationalized names
+// must be given in their punycode (xn--) form. A single wildcard is allowed as the left-most label
+// when it is followed by at least two labels, such as *.example.com.
+func validateHostname(name string) error {
+	if name == "" {
+		return errors.New("empty name")
+	}
+	if len(name) > maxHostnameLength {
+		return fmt.Errorf("name exceeds %d characters", maxHostnameLength)
+	}
+
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "*" {
+			if i != 0 {
+				return errors.New("wildcard is only allowed as the left-most label")
+			}
+			if len(labels) < 3 {
+				return errors.New("wildcard must be followed by at least two labels")
+			}
+
+			continue
+		}
+
+		if err := validateLabel(label); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateLabel checks that the DNS label consists of 1 to 63 letters, digits and hyphens,
+// and does not start or end with a hyphen.
+func validateLabel(label string) error {
+	if label == "" {
+		return errors.New("empty label")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("label %q exceeds 63 characters", label)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	}
+
+	for _, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+		case r == '*':
+			return fmt.Errorf("label %q must not contain a partial wildcard", label)
+		case r > 127:
+			return fmt.Errorf("label %q must be punycode encoded", label)
+		default:
+			return fmt.Errorf("label %q contains invalid character %q", label, r)
+		}
+	}
+
+	return nil
+}
+
+// parseEmails validates the email addresses. The returned error lists every invalid entry.
+func parseEmails(emails []string) ([]string, error) {
+	var invalid []string
+	for _, item := range emails {
+		addr, err := mail.ParseAddress(item)
+		if err != nil || addr.Address != item {
+			invalid = append(invalid, fmt.Sprintf("%q", item))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid emails: %s", strings.Join(invalid, ", "))
+	}
+
+	return emails, nil
+}
+
+// parseURIs parses the URIs, which must be absolute. The returned error lists every invalid entry.
+func parseURIs(uris []string) ([]*url.URL, error) {
+	var (
+		invalid []string
+		res     []*url.URL
+	)
+	for _, item := range uris {
+		uri, err := url.Parse(item)
+		if err != nil || !uri.IsAbs() {
+			invalid = append(invalid, fmt.Sprintf("%q", item))
+			continue
+		}
+
+		res = append(res, uri)
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid uris: %s", strings.Join(invalid, ", "))
+	}
+
+	return res, nil
+}
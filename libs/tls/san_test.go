@@ -0,0 +1,94 @@
+package tls
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAddrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		wantDNS []string
+		wantIP  net.IP
+		wantErr string
+	}{
+		{name: "hostname", addr: "node.example.com", wantDNS: []string{"node.example.com"}},
+		{name: "hostname uppercase", addr: "Node.Example.COM", wantDNS: []string{"node.example.com"}},
+		{name: "single label", addr: "localhost", wantDNS: []string{"localhost"}},
+		{name: "wildcard", addr: "*.example.com", wantDNS: []string{"*.example.com"}},
+		{name: "wildcard two labels", addr: "*.com", wantErr: "wildcard must be followed by at least two labels"},
+		{name: "wildcard not left-most", addr: "node.*.example.com", wantErr: "wildcard is only allowed as the left-most label"},
+		{name: "partial wildcard", addr: "no*de.example.com", wantErr: "partial wildcard"},
+		{name: "double wildcard", addr: "*.*.example.com", wantErr: "wildcard is only allowed as the left-most label"},
+		{name: "punycode", addr: "xn--bcher-kva.example", wantDNS: []string{"xn--bcher-kva.example"}},
+		{name: "punycode wildcard", addr: "*.xn--bcher-kva.example", wantDNS: []string{"*.xn--bcher-kva.example"}},
+		{name: "unicode", addr: "bücher.example", wantErr: "must be punycode encoded"},
+		{name: "leading hyphen", addr: "-node.example.com", wantErr: "must not start or end with a hyphen"},
+		{name: "empty label", addr: "node..example.com", wantErr: "empty label"},
+		{name: "underscore", addr: "node_1.example.com", wantErr: "invalid character"},
+		{name: "long label", addr: strings.Repeat("a", 64) + ".example.com", wantErr: "exceeds 63 characters"},
+		{name: "long name", addr: strings.Repeat("a.", 127) + "ab", wantErr: "name exceeds 253 characters"},
+		{name: "ipv4", addr: "203.0.113.7", wantIP: net.ParseIP("203.0.113.7").To4()},
+		{name: "ipv6", addr: "2001:db8::7", wantIP: net.ParseIP("2001:db8::7")},
+		{name: "ipv4-mapped ipv6", addr: "::ffff:203.0.113.7", wantIP: net.ParseIP("203.0.113.7").To4()},
+		{name: "ipv6 zone", addr: "fe80::1%eth0", wantErr: "must not have a zone"},
+		{name: "bracketed ipv6", addr: "[2001:db8::7]", wantIP: net.ParseIP("2001:db8::7")},
+		{name: "bracketed ipv6 loopback", addr: "[::1]", wantIP: net.ParseIP("::1")},
+		{name: "bracketed ipv4", addr: "[203.0.113.7]", wantErr: "brackets must enclose an ipv6 address"},
+		{name: "bracketed ipv4-mapped ipv6", addr: "[::ffff:203.0.113.7]", wantErr: "brackets must enclose an ipv6 address"},
+		{name: "bracketed hostname", addr: "[node.example.com]", wantErr: "brackets must enclose an ipv6 address"},
+		{name: "bracketed ipv6 zone", addr: "[fe80::1%eth0]", wantErr: "must not have a zone"},
+		{name: "unbalanced open bracket", addr: "[2001:db8::7", wantErr: "unbalanced brackets"},
+		{name: "unbalanced close bracket", addr: "2001:db8::7]", wantErr: "unbalanced brackets"},
+		{name: "empty", addr: "", wantErr: "empty name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dnsNames, ipAddrs, err := parseAddrs([]string{tt.addr})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseAddrs(%q) error = %v, want error containing %q", tt.addr, err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAddrs(%q) error = %v", tt.addr, err)
+			}
+
+			if !reflect.DeepEqual(dnsNames, tt.wantDNS) {
+				t.Errorf("dns names = %v, want %v", dnsNames, tt.wantDNS)
+			}
+
+			var wantIPs []net.IP
+			if tt.wantIP != nil {
+				wantIPs = []net.IP{tt.wantIP}
+			}
+			if !reflect.DeepEqual(ipAddrs, wantIPs) {
+				t.Errorf("ip addrs = %v, want %v", ipAddrs, wantIPs)
+			}
+		})
+	}
+}
+
+func TestParseAddrsListsEveryInvalidEntry(t *testing.T) {
+	_, _, err := parseAddrs([]string{"node.example.com", "bad_name", "[1.2.3.4]", "127.0.0.1"})
+	if err == nil {
+		t.Fatal("parseAddrs() succeeded, want error")
+	}
+
+	for _, item := range []string{`"bad_name"`, `"[1.2.3.4]"`} {
+		if !strings.Contains(err.Error(), item) {
+			t.Errorf("error %q does not list %s", err, item)
+		}
+	}
+	for _, item := range []string{"node.example.com", "127.0.0.1"} {
+		if strings.Contains(err.Error(), item) {
+			t.Errorf("error %q lists valid entry %s", err, item)
+		}
+	}
+}
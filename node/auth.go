@@ -0,0 +1,181 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/qubetics/qubetics-go-sdk/utils"
+)
+
+// VerifyRequestSignature checks whether the Base64-encoded signature is valid for the message and the encoded public key.
+func VerifyRequestSignature(msg []byte, pubKey, signature string) error {
+	// Decode the public key.
+	key, err := utils.DecodePubKey(pubKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	// Decode the signature from Base64.
+	buf, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	// Verify the signature against the message and public key.
+	if !key.VerifySignature(msg, buf) {
+		return errors.New("signature verification failed")
+	}
+
+	return nil
+}
+
+const (
+	// MaxSignedRequestBodySize is the maximum size in bytes of a request body read by VerifySignatureMiddleware.
+	MaxSignedRequestBodySize = 1 << 20
+
+	// MaxSignedRequestAge is the maximum difference between the timestamp of a signed request and the time
+	// it is verified, which bounds the window in which a captured request can be replayed.
+	MaxSignedRequestAge = 5 * time.Minute
+)
+
+// SignedRequestBody represents the standard envelope for signed node API requests.
+type SignedRequestBody struct {
+	Data      string `json:"data" binding:"required,base64,gt=0"`      // Encoded request data (Base64 format), must be present and non-empty.
+	PubKey    string `json:"pub_key" binding:"required,gt=0"`          // Public key of the signer, required and non-empty.
+	Signature string `json:"signature" binding:"required,base64,gt=0"` // Signature of the bytes returned by SignBytes, must be in Base64 format.
+	Timestamp int64  `json:"timestamp" binding:"required,gt=0"`        // Unix time in seconds at which the request was signed.
+}
+
+// AccAddr converts the public key into a Cosmos SDK AccAddress.
+func (r *SignedRequestBody) AccAddr() (types.AccAddress, error) {
+	// Decode the public key.
+	pubKey, err := utils.DecodePubKey(r.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	return pubKey.Address().Bytes(), nil
+}
+
+// DecodeData decodes the Base64-encoded JSON string into the provided target structure.
+func (r *SignedRequestBody) DecodeData(target interface{}) error {
+	buf, err := r.Msg()
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(buf, target); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	return nil
+}
+
+// EncodeData marshals the given data into JSON and encodes it in Base64.
+func (r *SignedRequestBody) EncodeData(data interface{}) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	// Encode JSON to Base64.
+	r.Data = base64.StdEncoding.EncodeToString(buf)
+	return nil
+}
+
+// Msg returns the decoded data, which is the message covered by the signature.
+func (r *SignedRequestBody) Msg() ([]byte, error) {
+	buf, err := base64.StdEncoding.DecodeString(r.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	return buf, nil
+}
+
+// SignBytes returns the bytes covered by the signature, which bind the decoded data to the HTTP method and
+// URL path of the request and to its timestamp, so that it cannot be replayed against another endpoint.
+func (r *SignedRequestBody) SignBytes(method, path string) ([]byte, error) {
+	msg, err := r.Msg()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+	buf = append(buf, method...)
+	buf = append(buf, '\n')
+	buf = append(buf, path...)
+	buf = append(buf, '\n')
+	buf = strconv.AppendInt(buf, r.Timestamp, 10)
+	buf = append(buf, '\n')
+	buf = append(buf, msg...)
+
+	return buf, nil
+}
+
+// Verify checks whether the signature is valid for the request with the given method and URL path, and
+// whether the timestamp is within MaxSignedRequestAge of the current time.
+func (r *SignedRequestBody) Verify(method, path string) error {
+	if age := time.Since(time.Unix(r.Timestamp, 0)); age > MaxSignedRequestAge || age < -MaxSignedRequestAge {
+		return fmt.Errorf("timestamp %d is outside the allowed window of %s", r.Timestamp, MaxSignedRequestAge)
+	}
+
+	msg, err := r.SignBytes(method, path)
+	if err != nil {
+		return err
+	}
+
+	return VerifyRequestSignature(msg, r.PubKey, r.Signature)
+}
+
+// signedRequestKey is the context key for the verified signed request body.
+type signedRequestKey struct{}
+
+// SignedRequestFromContext returns the verified signed request body stored by VerifySignatureMiddleware.
+func SignedRequestFromContext(ctx context.Context) (*SignedRequestBody, bool) {
+	v, ok := ctx.Value(signedRequestKey{}).(*SignedRequestBody)
+	return v, ok
+}
+
+// VerifySignatureMiddleware returns an HTTP middleware that decodes the request body as a SignedRequestBody
+// and rejects the request if the signature is invalid for its method and URL path, or if its timestamp is stale.
+// Bodies larger than MaxSignedRequestBodySize are rejected before being verified. The verified body is stored
+// in the request context, and the request body is restored so that handlers can read it again.
+func VerifySignatureMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSignedRequestBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		var body SignedRequestBody
+		if err := json.Unmarshal(buf, &body); err != nil {
+			http.Error(w, "failed to decode request body", http.StatusBadRequest)
+			return
+		}
+		if err := body.Verify(r.Method, r.URL.Path); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(buf))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signedRequestKey{}, &body)))
+	})
+}
@@ -13,16 +13,20 @@ import (
 // Client is a struct for interacting with nodes.
 type Client struct {
 	*core.Client
-	addr     types.NodeAddress
-	fromName string
-	insecure bool
-	timeout  time.Duration
+	addr        types.NodeAddress
+	fromName    string
+	insecure    bool
+	remoteURL   string
+	timeout     time.Duration
+	urlCache    *urlCache
+	urlCacheTTL time.Duration
 }
 
 // NewClient creates a new instance of Client.
 func NewClient(c *core.Client) *Client {
 	return &Client{
-		Client: c,
+		Client:   c,
+		urlCache: &urlCache{},
 	}
 }
 
@@ -44,12 +48,25 @@ func (c *Client) WithInsecure(insecure bool) *Client {
 	return c
 }
 
+// WithRemoteURL sets the base URL of the node's API, skipping the on-chain lookup, and returns the updated instance.
+func (c *Client) WithRemoteURL(remoteURL string) *Client {
+	c.remoteURL = remoteURL
+	return c
+}
+
 // WithTimeout sets the timeout of the Client and returns the updated instance.
 func (c *Client) WithTimeout(timeout time.Duration) *Client {
 	c.timeout = timeout
 	return c
 }
 
+// WithURLCacheTTL sets how long the resolved remote URL of the node is cached and returns the updated instance.
+// A zero TTL caches the URL until it is invalidated by a connection error or RefreshURL.
+func (c *Client) WithURLCacheTTL(ttl time.Duration) *Client {
+	c.urlCacheTTL = ttl
+	return c
+}
+
 // NewClientFromConfig creates a new Client instance based on the provided configuration.
 func NewClientFromConfig(c *config.Config) (*Client, error) {
 	cc, err := core.NewClientFromConfig(c)
@@ -71,7 +88,9 @@ func NewClientFromConfig(c *config.Config) (*Client, error) {
 		WithAddr(nil).
 		WithFromName(fromName).
 		WithInsecure(false).
-		WithTimeout(c.RPC.GetTimeout())
+		WithRemoteURL("").
+		WithTimeout(c.RPC.GetTimeout()).
+		WithURLCacheTTL(0)
 
 	return v, nil
 }
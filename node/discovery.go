@@ -0,0 +1,117 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	qubetics "github.com/qubetics/qubetics-blockchain/v2/types"
+)
+
+// DefaultFetchConcurrency is the default number of nodes queried concurrently by FetchNodeInfos.
+const DefaultFetchConcurrency = 8
+
+// FetchError reports the nodes whose information could not be fetched.
+type FetchError struct {
+	Errors map[string]error // Errors keyed by the Bech32-encoded node address.
+}
+
+// Error implements the error interface.
+func (e *FetchError) Error() string {
+	addrs := make([]string, 0, len(e.Errors))
+	for addr := range e.Errors {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Strings(addrs)
+
+	items := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		items = append(items, fmt.Sprintf("%s: %v", addr, e.Errors[addr]))
+	}
+
+	return fmt.Sprintf("failed to fetch info of %d nodes: %s", len(addrs), strings.Join(items, "; "))
+}
+
+// forNode returns a copy of the client targeting the node address, with its own remote URL cache.
+func (c *Client) forNode(addr qubetics.NodeAddress) *Client {
+	v := *c
+	v.addr = addr
+	v.remoteURL = ""
+	v.urlCache = &urlCache{}
+
+	return &v
+}
+
+// FetchNodeInfos retrieves the information of the nodes concurrently, with at most concurrency
+// requests in flight. Each node gets its own timeout covering both the URL lookup and the request.
+// The results are keyed by the Bech32-encoded node address, and failures are reported per node
+// through a *FetchError alongside the partial results.
+func (c *Client) FetchNodeInfos(ctx context.Context, nodes []qubetics.NodeAddress, concurrency int) (map[string]*GetInfoResult, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	var (
+		res  = make(map[string]*GetInfoResult)
+		errs = make(map[string]error)
+		mu   sync.Mutex
+		sem  = make(chan struct{}, concurrency)
+		wg   sync.WaitGroup
+	)
+
+	// set records the outcome of the request for the node address.
+	set := func(addr string, info *GetInfoResult, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs[addr] = err
+			return
+		}
+
+		res[addr] = info
+	}
+
+	seen := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		addr := node.String()
+		if seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+
+		select {
+		case <-ctx.Done():
+			set(addr, nil, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(node qubetics.NodeAddress, addr string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			// Bound the whole lookup, so that one slow node does not block the batch.
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			info, err := c.forNode(node).GetInfo(ctx)
+			set(addr, info, err)
+		}(node, addr)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return res, &FetchError{Errors: errs}
+	}
+
+	return res, nil
+}
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/qubetics/qubetics-go-sdk/types"
+	"github.com/qubetics/qubetics-go-sdk/utils"
 )
 
 // do performs an HTTP request with the given parameters and decodes the response.
@@ -51,6 +55,8 @@ func (c *Client) do(ctx context.Context, method, url string, reqBody, result int
 	// Perform the HTTP request.
 	resp, err := client.Do(req)
 	if err != nil {
+		// The node may have moved, so resolve its remote URL again on the next request.
+		c.urlCache.invalidate()
 		return fmt.Errorf("failed to perform request: %w", err)
 	}
 
@@ -69,12 +75,8 @@ func (c *Client) do(ctx context.Context, method, url string, reqBody, result int
 
 	// Decode the Result field if a result target is provided.
 	if result != nil {
-		buf, err := json.Marshal(respBody.Result)
-		if err != nil {
-			return fmt.Errorf("failed to encode data: %w", err)
-		}
-		if err := json.Unmarshal(buf, result); err != nil {
-			return fmt.Errorf("failed to decode result: %w", err)
+		if err := respBody.DecodeResult(result); err != nil {
+			return err
 		}
 	}
 
@@ -82,16 +84,72 @@ func (c *Client) do(ctx context.Context, method, url string, reqBody, result int
 }
 
 // getURL constructs the full URL for a node with an optional path.
+// The remote URL override is used if set, otherwise the node's remote URL is resolved from the chain.
 func (c *Client) getURL(ctx context.Context, pathSuffix string) (string, error) {
-	node, err := c.Node(ctx, c.addr)
-	if err != nil {
-		return "", fmt.Errorf("failed to query node: %w", err)
+	remoteURL := c.remoteURL
+	if remoteURL == "" {
+		var err error
+		if remoteURL, err = c.resolveURL(ctx, false); err != nil {
+			return "", err
+		}
 	}
 
-	path, err := url.JoinPath(node.RemoteURL, pathSuffix)
+	path, err := url.JoinPath(remoteURL, pathSuffix)
 	if err != nil {
 		return "", fmt.Errorf("failed to join url path: %w", err)
 	}
 
 	return path, nil
 }
+
+// isNotFound reports whether the error is an API error with the not found code.
+func isNotFound(err error) bool {
+	var e *types.Error
+	return errors.As(err, &e) && e.Code == http.StatusNotFound
+}
+
+// sign signs the message using the client's key and returns the encoded public key and the Base64-encoded signature.
+func (c *Client) sign(msg []byte) (pubKey, signature string, err error) {
+	buf, key, err := c.Sign(c.fromName, msg)
+	if err != nil {
+		return "", "", err
+	}
+
+	return utils.EncodePubKey(key), base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// doSigned wraps the request data in a SignedRequestBody signed with the client's key and
+// performs an HTTP request against the given path of the node's API.
+func (c *Client) doSigned(ctx context.Context, method, pathSuffix string, data, result interface{}) error {
+	// Encode the request data into Base64 format.
+	req := SignedRequestBody{
+		Timestamp: time.Now().Unix(),
+	}
+	if err := req.EncodeData(data); err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+
+	// Retrieve the API endpoint URL.
+	path, err := c.getURL(ctx, pathSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to get url: %w", err)
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	// Sign the data bound to the method, URL path and timestamp using the client's private key.
+	msg, err := req.SignBytes(method, u.Path)
+	if err != nil {
+		return err
+	}
+
+	req.PubKey, req.Signature, err = c.sign(msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign request data: %w", err)
+	}
+
+	return c.do(ctx, method, path, &req, result)
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/qubetics/qubetics-go-sdk/libs/geoip"
+)
+
+// NodeInfos is a list of node information results that can be filtered and sorted.
+type NodeInfos []*GetInfoResult
+
+// FilterByCountry returns the nodes located in the country with the given ISO 3166-1 alpha-2 code.
+// The comparison is case-insensitive, and nodes without a location are excluded.
+func (n NodeInfos) FilterByCountry(countryCode string) NodeInfos {
+	var res NodeInfos
+	for _, item := range n {
+		if item.Location != nil && strings.EqualFold(item.Location.CountryCode, countryCode) {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+// SortByDistance orders the nodes in place by great-circle distance from the client location,
+// nearest first. Nodes without a location are placed at the end.
+func (n NodeInfos) SortByDistance(clientLoc *geoip.Location) {
+	sort.SliceStable(n, func(i, j int) bool {
+		a, b := n[i].Location, n[j].Location
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+
+		return geoip.Haversine(clientLoc, a) < geoip.Haversine(clientLoc, b)
+	})
+}
@@ -2,8 +2,6 @@ package node
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
 	"github.com/qubetics/qubetics-go-sdk/libs/geoip"
 	"github.com/qubetics/qubetics-go-sdk/types"
@@ -30,18 +28,12 @@ func (r *GetInfoResult) GetType() types.ServiceType {
 
 // GetInfo retrieves detailed information about a specific node.
 func (c *Client) GetInfo(ctx context.Context) (*GetInfoResult, error) {
-	// Get the API endpoint URL for retrieving node information.
-	path, err := c.getURL(ctx, "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get url: %w", err)
-	}
-
 	// Send an HTTP GET request to fetch node details.
-	var res GetInfoResult
-	if err := c.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+	res, _, err := c.probe(ctx)
+	if err != nil {
 		return nil, err
 	}
 
 	// Return the retrieved node information.
-	return &res, nil
+	return res, nil
 }
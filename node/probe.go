@@ -0,0 +1,209 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	qubetics "github.com/qubetics/qubetics-blockchain/v2/types"
+
+	"github.com/qubetics/qubetics-go-sdk/libs/geoip"
+)
+
+// RankCriteria defines the weights used to rank nodes. Each metric is normalized to the
+// range [0, 1] across the probed nodes, and the weighted sum forms the score (lower is better).
+type RankCriteria struct {
+	Concurrency    int             // Maximum number of nodes probed concurrently.
+	DistanceWeight float64         // Weight of the distance between the client and the node.
+	LatencyWeight  float64         // Weight of the round-trip time to the node's API.
+	LoadWeight     float64         // Weight of the number of peers connected to the node.
+	Origin         *geoip.Location // Location of the client, required for the distance metric.
+}
+
+// DefaultRankCriteria returns criteria that rank nodes by latency and load.
+func DefaultRankCriteria() *RankCriteria {
+	return &RankCriteria{
+		Concurrency:    DefaultFetchConcurrency,
+		DistanceWeight: 0,
+		LatencyWeight:  1,
+		LoadWeight:     0.5,
+		Origin:         nil,
+	}
+}
+
+// NodeRank holds the probe results and score of a node, so that callers can show why it ranked where it did.
+type NodeRank struct {
+	Addr     string         `json:"addr"`               // Bech32-encoded address of the node.
+	Distance float64        `json:"distance,omitempty"` // Distance between the client and the node in kilometers.
+	Err      error          `json:"-"`                  // Error encountered while probing the node, if any. Encoded as "error".
+	Info     *GetInfoResult `json:"info,omitempty"`     // Information reported by the node.
+	Latency  time.Duration  `json:"latency"`            // Round-trip time to the node's API.
+	Score    float64        `json:"score"`              // Weighted score of the node (lower is better).
+}
+
+// MarshalJSON encodes the NodeRank, with the probe error as the "error" string field.
+func (r *NodeRank) MarshalJSON() ([]byte, error) {
+	type nodeRank NodeRank
+
+	v := struct {
+		*nodeRank
+		Error string `json:"error,omitempty"`
+	}{
+		nodeRank: (*nodeRank)(r),
+	}
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	}
+
+	return json.Marshal(v)
+}
+
+// probe performs a timed request to the node's API and returns the reported information and the round-trip time.
+// The remote URL is resolved beforehand, so that the chain query is not part of the measurement.
+func (c *Client) probe(ctx context.Context) (*GetInfoResult, time.Duration, error) {
+	path, err := c.getURL(ctx, "")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	start := time.Now()
+
+	var res GetInfoResult
+	if err := c.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+		return nil, 0, err
+	}
+
+	return &res, time.Since(start), nil
+}
+
+// ProbeNode measures the round-trip time to the API of the node.
+func (c *Client) ProbeNode(ctx context.Context, node qubetics.NodeAddress) (time.Duration, error) {
+	_, latency, err := c.forNode(node).probe(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return latency, nil
+}
+
+// RankNodes probes the nodes concurrently and returns them ordered by score according to the criteria.
+// Nodes that could not be probed are placed at the end with their error set. If no node could be probed,
+// the ranks are returned along with an error wrapping the error of the first node.
+func (c *Client) RankNodes(ctx context.Context, nodes []qubetics.NodeAddress, criteria *RankCriteria) ([]*NodeRank, error) {
+	if criteria == nil {
+		criteria = DefaultRankCriteria()
+	}
+	if criteria.DistanceWeight != 0 && criteria.Origin == nil {
+		return nil, errors.New("origin is required for the distance weight")
+	}
+
+	concurrency := criteria.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	var (
+		res  []*NodeRank
+		mu   sync.Mutex
+		sem  = make(chan struct{}, concurrency)
+		seen = make(map[string]bool, len(nodes))
+		wg   sync.WaitGroup
+	)
+
+	// Probe each distinct node, bounding the number of requests in flight.
+	for _, node := range nodes {
+		addr := node.String()
+		if seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+		rank := &NodeRank{Addr: addr}
+		res = append(res, rank)
+
+		select {
+		case <-ctx.Done():
+			rank.Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(node qubetics.NodeAddress, rank *NodeRank) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			info, latency, err := c.forNode(node).probe(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			rank.Info, rank.Latency, rank.Err = info, latency, err
+			if err == nil && criteria.Origin != nil && info.Location != nil {
+				rank.Distance = criteria.Origin.Distance(info.Location)
+			}
+		}(node, rank)
+	}
+
+	wg.Wait()
+
+	scoreNodes(res, criteria)
+
+	// Sort by score, keeping failed nodes at the end.
+	sort.SliceStable(res, func(i, j int) bool {
+		if (res[i].Err == nil) != (res[j].Err == nil) {
+			return res[i].Err == nil
+		}
+
+		return res[i].Score < res[j].Score
+	})
+
+	if len(res) == 0 {
+		return nil, errors.New("no nodes to rank")
+	}
+	if res[0].Err != nil {
+		return res, fmt.Errorf("failed to probe any of %d nodes: %w", len(res), res[0].Err)
+	}
+
+	return res, nil
+}
+
+// scoreNodes computes the weighted score of the successfully probed nodes.
+func scoreNodes(ranks []*NodeRank, criteria *RankCriteria) {
+	var maxDistance, maxLatency, maxLoad float64
+	for _, rank := range ranks {
+		if rank.Err != nil {
+			continue
+		}
+
+		maxDistance = max(maxDistance, rank.Distance)
+		maxLatency = max(maxLatency, float64(rank.Latency))
+		maxLoad = max(maxLoad, float64(rank.Info.Peers))
+	}
+
+	// normalize scales the value into the range [0, 1] relative to the maximum.
+	normalize := func(v, limit float64) float64 {
+		if limit == 0 {
+			return 0
+		}
+
+		return v / limit
+	}
+
+	for _, rank := range ranks {
+		if rank.Err != nil {
+			continue
+		}
+
+		rank.Score = criteria.DistanceWeight*normalize(rank.Distance, maxDistance) +
+			criteria.LatencyWeight*normalize(float64(rank.Latency), maxLatency) +
+			criteria.LoadWeight*normalize(float64(rank.Info.Peers), maxLoad)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -67,24 +66,7 @@ func (r *AddSessionRequestBody) Msg() (buf []byte) {
 
 // Verify checks whether the provided signature is valid for the given message and public key.
 func (r *AddSessionRequestBody) Verify() error {
-	// Decode the public key.
-	pubKey, err := utils.DecodePubKey(r.PubKey)
-	if err != nil {
-		return fmt.Errorf("failed to decode public key: %w", err)
-	}
-
-	// Decode the signature from Base64.
-	signature, err := base64.StdEncoding.DecodeString(r.Signature)
-	if err != nil {
-		return fmt.Errorf("failed to decode signature: %w", err)
-	}
-
-	// Verify the signature against the message and public key.
-	if !pubKey.VerifySignature(r.Msg(), signature) {
-		return errors.New("signature verification failed")
-	}
-
-	return nil
+	return VerifyRequestSignature(r.Msg(), r.PubKey, r.Signature)
 }
 
 // AddSessionResult represents the response for adding a session.
@@ -132,14 +114,14 @@ func (c *Client) AddSession(ctx context.Context, id uint64, data interface{}) (*
 	}
 
 	// Sign the session message using the client's private key.
-	signature, pubKey, err := c.Sign(c.fromName, req.Msg())
+	pubKey, signature, err := c.sign(req.Msg())
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign session data: %w", err)
 	}
 
-	// Set the public key and Base64-encoded signature in the request.
-	req.PubKey = utils.EncodePubKey(pubKey)
-	req.Signature = base64.StdEncoding.EncodeToString(signature)
+	// Set the public key and signature in the request.
+	req.PubKey = pubKey
+	req.Signature = signature
 
 	// Retrieve the API endpoint URL for adding a session.
 	path, err := c.getURL(ctx, "sessions")
@@ -156,3 +138,20 @@ func (c *Client) AddSession(ctx context.Context, id uint64, data interface{}) (*
 	// Return the response containing session details.
 	return &res, nil
 }
+
+// DeleteSessionRequestData represents the signed data for removing a session.
+type DeleteSessionRequestData struct {
+	ID uint64 `json:"id"` // Unique identifier of the session to remove.
+}
+
+// DeleteSession asks the node to remove a session, disconnecting the peer immediately instead of
+// waiting for the session to expire. A session that does not exist on the node is treated as removed.
+func (c *Client) DeleteSession(ctx context.Context, id uint64) error {
+	// Send the signed HTTP DELETE request to remove the session.
+	err := c.doSigned(ctx, http.MethodDelete, fmt.Sprintf("sessions/%d", id), &DeleteSessionRequestData{ID: id}, nil)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
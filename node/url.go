@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// urlCache holds the remote URL resolved from the chain for a node address.
+type urlCache struct {
+	mu        sync.Mutex
+	addr      string
+	expiresAt time.Time
+	url       string
+}
+
+// get returns the cached remote URL if it belongs to the address and has not expired.
+func (u *urlCache) get(addr string) (string, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.url == "" || u.addr != addr {
+		return "", false
+	}
+	if !u.expiresAt.IsZero() && time.Now().After(u.expiresAt) {
+		return "", false
+	}
+
+	return u.url, true
+}
+
+// set caches the remote URL for the address, expiring after the TTL if it is positive.
+func (u *urlCache) set(addr, url string, ttl time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.addr = addr
+	u.url = url
+	u.expiresAt = time.Time{}
+	if ttl > 0 {
+		u.expiresAt = time.Now().Add(ttl)
+	}
+}
+
+// invalidate clears the cached remote URL.
+func (u *urlCache) invalidate() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.url = ""
+}
+
+// resolveURL returns the remote URL of the node, querying the chain if it is not cached or if force is set.
+func (c *Client) resolveURL(ctx context.Context, force bool) (string, error) {
+	addr := c.addr.String()
+	if !force {
+		if v, ok := c.urlCache.get(addr); ok {
+			return v, nil
+		}
+	}
+
+	node, err := c.Node(ctx, c.addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to query node: %w", err)
+	}
+	if node == nil {
+		return "", fmt.Errorf("node %s does not exist", c.addr)
+	}
+
+	c.urlCache.set(addr, node.RemoteURL, c.urlCacheTTL)
+	return node.RemoteURL, nil
+}
+
+// RefreshURL queries the chain for the node's remote URL and replaces the cached value.
+func (c *Client) RefreshURL(ctx context.Context) error {
+	_, err := c.resolveURL(ctx, true)
+	return err
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -15,6 +16,11 @@ func (e *Error) String() string {
 	return fmt.Sprintf("code=%d, message=%s", e.Code, e.Message)
 }
 
+// Error implements the error interface, allowing callers to inspect the code with errors.As.
+func (e *Error) Error() string {
+	return e.String()
+}
+
 // NewError creates a new Error with the given code and message.
 func NewError(code int, msg string) *Error {
 	return &Error{
@@ -24,18 +30,83 @@ func NewError(code int, msg string) *Error {
 }
 
 // Response standardizes API response structures.
+// When a Response is unmarshalled, Result holds the raw JSON of the result as a json.RawMessage,
+// which DecodeResult decodes into its target in a single step.
 type Response struct {
 	Success bool        `json:"success"`          // Success status of the operation
 	Error   *Error      `json:"error,omitempty"`  // Details of any error that occurred
 	Result  interface{} `json:"result,omitempty"` // Result data of the operation
 }
 
+// UnmarshalJSON decodes the response, keeping the result as a json.RawMessage.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Success bool            `json:"success"`
+		Error   *Error          `json:"error,omitempty"`
+		Result  json.RawMessage `json:"result,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	r.Success, r.Error, r.Result = v.Success, v.Error, nil
+	if len(v.Result) > 0 && string(v.Result) != "null" {
+		r.Result = v.Result
+	}
+
+	return nil
+}
+
+// DecodeResult decodes the result into the target. Raw results from an unmarshalled response are
+// decoded directly, while results set in memory are converted through JSON.
+// A missing result leaves the target unchanged.
+func (r *Response) DecodeResult(target interface{}) error {
+	var buf []byte
+	switch v := r.Result.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		buf = v
+	default:
+		var err error
+		if buf, err = json.Marshal(v); err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(buf, target); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeResult decodes the result of the response into a value of type T.
+// It returns the error of the response if it was not successful.
+func DecodeResult[T any](resp *Response) (T, error) {
+	var v T
+	if err := resp.Err(); err != nil {
+		return v, err
+	}
+
+	// Return results of the requested type set in memory without conversion.
+	if result, ok := resp.Result.(T); ok {
+		return result, nil
+	}
+
+	if err := resp.DecodeResult(&v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
 func (r *Response) Err() error {
 	if r.Success {
 		return nil
 	}
 	if r.Error != nil {
-		return errors.New(r.Error.String())
+		return r.Error
 	}
 
 	return errors.New("unknown error")
@@ -67,3 +138,9 @@ func NewResponseResult(v interface{}) *Response {
 		Result:  v,
 	}
 }
+
+// NewTypedResponseResult returns a Response indicating a success with the provided result data,
+// constraining the result to the type T expected by DecodeResult.
+func NewTypedResponseResult[T any](v T) *Response {
+	return NewResponseResult(v)
+}
@@ -7,33 +7,66 @@ import (
 	"strings"
 )
 
+// Port represents a mapping of inbound ports to outbound ports. The four range fields hold the first
+// range, and Extra holds the additional ranges of a port list such as "443,8443" or "1000-2000,3000".
 type Port struct {
 	InFrom  uint16 `json:"in_from"`
 	InTo    uint16 `json:"in_to"`
 	OutFrom uint16 `json:"out_from"`
 	OutTo   uint16 `json:"out_to"`
+	Extra   []Port `json:"extra,omitempty"` // Extra holds the ranges after the first one, in ascending order.
 }
 
-// InPort returns a string representation of the input port range.
+// Ranges returns every range of the port list, starting with the first one, without nested Extra values.
+func (p Port) Ranges() []Port {
+	ranges := []Port{{InFrom: p.InFrom, InTo: p.InTo, OutFrom: p.OutFrom, OutTo: p.OutTo}}
+	for _, r := range p.Extra {
+		ranges = append(ranges, Port{InFrom: r.InFrom, InTo: r.InTo, OutFrom: r.OutFrom, OutTo: r.OutTo})
+	}
+
+	return ranges
+}
+
+// formatRange returns the string representation of a single port range.
+func formatRange(from, to uint16) string {
+	if from == to {
+		return fmt.Sprintf("%d", from)
+	}
+
+	return fmt.Sprintf("%d-%d", from, to)
+}
+
+// InPort returns a string representation of the input port ranges, separated by commas.
 func (p Port) InPort() string {
-	if p.InFrom == p.InTo {
-		return fmt.Sprintf("%d", p.InFrom)
+	var items []string
+	for _, r := range p.Ranges() {
+		items = append(items, formatRange(r.InFrom, r.InTo))
 	}
 
-	return fmt.Sprintf("%d-%d", p.InFrom, p.InTo)
+	return strings.Join(items, ",")
 }
 
-// OutPort returns a string representation of the output port range.
+// OutPort returns a string representation of the output port ranges, separated by commas.
 func (p Port) OutPort() string {
-	if p.OutFrom == p.OutTo {
-		return fmt.Sprintf("%d", p.OutFrom)
+	var items []string
+	for _, r := range p.Ranges() {
+		items = append(items, formatRange(r.OutFrom, r.OutTo))
 	}
 
-	return fmt.Sprintf("%d-%d", p.OutFrom, p.OutTo)
+	return strings.Join(items, ",")
 }
 
 // String provides a string representation of the Port struct.
 func (p Port) String() string {
+	if len(p.Extra) > 0 {
+		in, out := p.InPort(), p.OutPort()
+		if in == out {
+			return in
+		}
+
+		return fmt.Sprintf("%s:%s", in, out)
+	}
+
 	switch {
 	case p.InFrom == p.InTo && p.OutFrom == p.OutTo && p.InFrom == p.OutFrom:
 		return fmt.Sprintf("%d", p.InFrom)
@@ -48,8 +81,8 @@ func (p Port) String() string {
 	}
 }
 
-// Validate checks if the Port struct values are valid.
-func (p Port) Validate() error {
+// validateRange checks if the values of a single port range are valid.
+func (p Port) validateRange() error {
 	if p.InFrom < 1 || p.InTo > 65535 || p.OutFrom < 1 || p.OutTo > 65535 {
 		return errors.New("numbers must be between 1 and 65535")
 	}
@@ -66,7 +99,33 @@ func (p Port) Validate() error {
 	return nil
 }
 
+// Validate checks if the Port struct values are valid, and that the ranges of a port list are
+// in ascending order without overlaps on both the in and out sides.
+func (p Port) Validate() error {
+	ranges := p.Ranges()
+	for i, r := range ranges {
+		if err := r.validateRange(); err != nil {
+			return err
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := ranges[i-1]
+		if r.InFrom <= prev.InTo {
+			return fmt.Errorf("in range %s must be greater than %s", formatRange(r.InFrom, r.InTo), formatRange(prev.InFrom, prev.InTo))
+		}
+		if r.OutFrom <= prev.OutTo {
+			return fmt.Errorf("out range %s must be greater than %s", formatRange(r.OutFrom, r.OutTo), formatRange(prev.OutFrom, prev.OutTo))
+		}
+	}
+
+	return nil
+}
+
 // NewPortFromString parses a port string and returns a Port struct if the string is valid.
+// Both sides of the optional "in:out" mapping can be comma-separated lists of values and ranges,
+// such as "443,8443" or "1000-2000,3000:5000-6000,7000", with the same number of items on each side.
 func NewPortFromString(portStr string) (Port, error) {
 	portStr = strings.TrimSpace(portStr)
 	if portStr == "" {
@@ -78,28 +137,41 @@ func NewPortFromString(portStr string) (Port, error) {
 		return Port{}, errors.New("invalid format")
 	}
 
-	inRange := parts[0]
+	inRanges := strings.Split(parts[0], ",")
 
-	outRange := inRange
+	outRanges := inRanges
 	if len(parts) == 2 {
-		outRange = parts[1]
+		outRanges = strings.Split(parts[1], ",")
 	}
 
-	inFrom, inTo, err := parseRange(inRange)
-	if err != nil {
-		return Port{}, fmt.Errorf("invalid in range: %w", err)
+	if len(inRanges) != len(outRanges) {
+		return Port{}, errors.New("in and out lists must have the same number of items")
 	}
 
-	outFrom, outTo, err := parseRange(outRange)
-	if err != nil {
-		return Port{}, fmt.Errorf("invalid out range: %w", err)
-	}
+	var port Port
+	for i := range inRanges {
+		inFrom, inTo, err := parseRange(inRanges[i])
+		if err != nil {
+			return Port{}, fmt.Errorf("invalid in range: %w", err)
+		}
 
-	port := Port{
-		InFrom:  inFrom,
-		InTo:    inTo,
-		OutFrom: outFrom,
-		OutTo:   outTo,
+		outFrom, outTo, err := parseRange(outRanges[i])
+		if err != nil {
+			return Port{}, fmt.Errorf("invalid out range: %w", err)
+		}
+
+		r := Port{
+			InFrom:  inFrom,
+			InTo:    inTo,
+			OutFrom: outFrom,
+			OutTo:   outTo,
+		}
+
+		if i == 0 {
+			port = r
+		} else {
+			port.Extra = append(port.Extra, r)
+		}
 	}
 
 	if err := port.Validate(); err != nil {
@@ -1,94 +1,212 @@
 package types
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net/netip"
+	"sort"
 	"sync"
 )
 
-// IPPool manages a pool of IP addresses, including assigned, reserved, and unassigned addresses.
-// It ensures thread-safe operations and manages address allocation and deallocation.
+var (
+	ErrAddrOutsidePrefix   = errors.New("addr is outside of prefix")            // ErrAddrOutsidePrefix is returned for addresses outside the pool prefix.
+	ErrAddrUnavailable     = errors.New("addr is already assigned or excluded") // ErrAddrUnavailable is returned when an address cannot be reserved.
+	ErrAddrNotAssigned     = errors.New("addr is not assigned")                 // ErrAddrNotAssigned is returned when releasing an address that was never reserved.
+	ErrAddrAlreadyReleased = errors.New("addr is already released")             // ErrAddrAlreadyReleased is returned when an address is released twice.
+	ErrPoolExhausted       = errors.New("pool is empty")                        // ErrPoolExhausted is returned when no address is available.
+)
+
+// IPPool manages a pool of IP addresses, including assigned, excluded, and released addresses.
+// All methods are safe for concurrent use.
+//
+// By default, the pool assigns single addresses (/32 for IPv4, /128 for IPv6). A pool created with
+// NewIPPoolWithPeerBits instead assigns blocks of addresses, such as a /112 per peer from an IPv6 /64,
+// each identified by its first address. Blocks give each peer a routable prefix of its own, at the cost
+// of fewer peers per pool: a /64 holds 2^48 /112 blocks, against 2^64 /128 addresses. Excluding an
+// address excludes its whole block, so the server address takes up a block.
 type IPPool struct {
-	assigned   map[netip.Addr]bool // Tracks IPs that are currently assigned.
-	reserved   map[netip.Addr]bool // Tracks IPs that are reserved.
-	unassigned []netip.Addr        // List of unassigned IPs available for allocation.
+	assigned map[netip.Addr]bool // Tracks IPs that are currently assigned.
+	excluded map[netip.Addr]bool // Tracks IPs that are never assigned, such as the network address.
+	released map[netip.Addr]bool // Tracks released IPs, to detect double releases.
+	free     []netip.Addr        // List of released IPs available for reuse.
 
-	addr   netip.Addr // Current IP address in the pool.
-	prefix *NetPrefix // The network prefix associated with the pool.
+	addr        netip.Addr // Next address in the pool that has never been assigned.
+	lowestFirst bool       // Always assign the lowest available address.
+	peerBits    int        // Prefix length of the address blocks assigned to peers.
+	prefix      *NetPrefix // The network prefix associated with the pool.
 
 	m *sync.Mutex // Mutex to ensure thread-safe access to the pool.
 }
 
-// NewIPPoolFromString creates a new IPPool using a given network prefix string.
-// It reserves the network address and, if applicable, the broadcast address for the prefix.
-func NewIPPoolFromString(s string) (*IPPool, error) {
-	prefix, err := NewNetPrefixFromString(s)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get net prefix: %w", err)
+// NewIPPool creates a new IPPool for the given network prefix.
+// It excludes the prefix address, which is the server's own address, the network address and, for IPv4,
+// the broadcast address, so that a pool for 10.5.0.1/30 only assigns 10.5.0.2.
+func NewIPPool(prefix *NetPrefix) (*IPPool, error) {
+	return NewIPPoolWithPeerBits(prefix, prefix.Addr().BitLen())
+}
+
+// NewIPPoolWithPeerBits creates a new IPPool for the given network prefix that assigns blocks with the
+// given prefix length, such as 112 or 128 for an IPv6 prefix. The blocks containing the prefix address,
+// the network address and, for IPv4, the broadcast address are excluded.
+// Returns an error if the length is shorter than the prefix or longer than the address.
+func NewIPPoolWithPeerBits(prefix *NetPrefix, peerBits int) (*IPPool, error) {
+	if peerBits < prefix.Bits() || peerBits > prefix.Addr().BitLen() {
+		return nil, fmt.Errorf("peer bits %d must be between %d and %d", peerBits, prefix.Bits(), prefix.Addr().BitLen())
 	}
 
 	p := &IPPool{
-		assigned:   make(map[netip.Addr]bool),
-		reserved:   make(map[netip.Addr]bool),
-		unassigned: []netip.Addr{},
-		addr:       prefix.NetworkAddr(),
-		prefix:     prefix,
-		m:          &sync.Mutex{},
+		assigned: make(map[netip.Addr]bool),
+		excluded: make(map[netip.Addr]bool),
+		released: make(map[netip.Addr]bool),
+		free:     []netip.Addr{},
+		addr:     prefix.NetworkAddr(),
+		peerBits: peerBits,
+		prefix:   prefix,
+		m:        &sync.Mutex{},
 	}
 
-	// Reserve the network and prefix address.
-	_ = p.Reserve(prefix.Addr())
-	_ = p.Reserve(prefix.NetworkAddr())
+	// Exclude the network and prefix address.
+	_ = p.Exclude(prefix.Addr())
+	_ = p.Exclude(prefix.NetworkAddr())
 
-	// For IPv4, reserve the broadcast address if it exists.
+	// For IPv4, exclude the broadcast address if it exists.
 	if p.addr.Is4() {
 		broadcast, err := prefix.BroadcastAddr()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get broadcast addr: %w", err)
 		}
 
-		_ = p.Reserve(broadcast)
+		_ = p.Exclude(broadcast)
 	}
 
 	return p, nil
 }
 
-// Reserve marks an IP address as reserved, ensuring it cannot be assigned.
-// Returns an error if the address is outside the prefix or already assigned/reserved.
-func (p *IPPool) Reserve(addr netip.Addr) error {
+// NewIPPoolFromString creates a new IPPool using a given network prefix string.
+// It excludes the server, network and, if applicable, broadcast addresses for the prefix, as NewIPPool does.
+func NewIPPoolFromString(s string) (*IPPool, error) {
+	prefix, err := NewNetPrefixFromString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get net prefix: %w", err)
+	}
+
+	return NewIPPool(prefix)
+}
+
+// PeerBits returns the prefix length of the address blocks assigned by the pool.
+func (p *IPPool) PeerBits() int {
+	return p.peerBits
+}
+
+// block returns the first address of the block containing the address.
+func (p *IPPool) block(addr netip.Addr) netip.Addr {
+	prefix, err := addr.Prefix(p.peerBits)
+	if err != nil {
+		return addr
+	}
+
+	return prefix.Addr()
+}
+
+// nextBlock returns the first address of the block following the one starting at the address,
+// or an invalid address if it overflows the address space.
+func (p *IPPool) nextBlock(addr netip.Addr) netip.Addr {
+	buf := addr.AsSlice()
+	hostBits := addr.BitLen() - p.peerBits
+
+	// Add one at the lowest bit of the block number, propagating the carry.
+	carry := 1 << (hostBits % 8)
+	for i := len(buf) - 1 - hostBits/8; i >= 0 && carry > 0; i-- {
+		sum := int(buf[i]) + carry
+		buf[i], carry = byte(sum), sum>>8
+	}
+	if carry > 0 {
+		return netip.Addr{}
+	}
+
+	next, _ := netip.AddrFromSlice(buf)
+	return next
+}
+
+// WithLowestFirst sets whether the pool always assigns the lowest available address, instead of
+// reusing released addresses in release order, and returns the updated IPPool instance.
+// This makes the allocation order independent of the release order, which is useful for reproducible tests.
+func (p *IPPool) WithLowestFirst(lowestFirst bool) *IPPool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.lowestFirst = lowestFirst
+	return p
+}
+
+// Exclude marks an IP address as excluded, ensuring it is never assigned.
+// Returns an error if the address is outside the prefix or already assigned or excluded.
+func (p *IPPool) Exclude(addr netip.Addr) error {
 	p.m.Lock()
 	defer p.m.Unlock()
 
 	if !p.prefix.Contains(addr) {
-		return errors.New("addr is outside of prefix")
+		return ErrAddrOutsidePrefix
 	}
-	if p.assigned[addr] || p.reserved[addr] {
-		return errors.New("addr is already assigned or reserved")
+
+	addr = p.block(addr)
+	if p.assigned[addr] || p.excluded[addr] {
+		return ErrAddrUnavailable
 	}
 
-	p.reserved[addr] = true
+	p.removeFree(addr)
+	p.excluded[addr] = true
+
 	return nil
 }
 
-// Get fetches an available IP address from the pool.
-// If there are no unassigned addresses, it increments the current address until one is found.
-func (p *IPPool) Get() (addr netip.Addr, err error) {
+// removeFree removes the address from the released addresses. The caller must hold the lock.
+func (p *IPPool) removeFree(addr netip.Addr) {
+	if !p.released[addr] {
+		return
+	}
+
+	delete(p.released, addr)
+	for i := range p.free {
+		if p.free[i] == addr {
+			p.free = append(p.free[:i], p.free[i+1:]...)
+			break
+		}
+	}
+}
+
+// Reserve assigns an available IP address from the pool.
+// Released addresses are reused first, then addresses that were never assigned are taken in order.
+func (p *IPPool) Reserve() (addr netip.Addr, err error) {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	// Check if there are preloaded unassigned addresses.
-	if len(p.unassigned) > 0 {
-		addr, p.unassigned = p.unassigned[0], p.unassigned[1:]
+	// Reuse a released address, which is always lower than the never-assigned ones.
+	if len(p.free) > 0 {
+		i := 0
+		if p.lowestFirst {
+			for j := range p.free {
+				if p.free[j].Less(p.free[i]) {
+					i = j
+				}
+			}
+		}
+
+		addr = p.free[i]
+		p.free = append(p.free[:i], p.free[i+1:]...)
+		delete(p.released, addr)
 	} else {
 		// Increment through addresses within the prefix until an available one is found.
 		for {
-			if !p.prefix.Contains(p.addr) {
-				return netip.Addr{}, errors.New("pool is empty")
+			if !p.addr.IsValid() || !p.prefix.Contains(p.addr) {
+				return netip.Addr{}, ErrPoolExhausted
 			}
 
-			addr, p.addr = p.addr, p.addr.Next()
-			if !p.reserved[addr] {
+			addr, p.addr = p.addr, p.nextBlock(p.addr)
+			if !p.excluded[addr] && !p.assigned[addr] {
 				break
 			}
 		}
@@ -98,19 +216,259 @@ func (p *IPPool) Get() (addr netip.Addr, err error) {
 	return addr, nil
 }
 
-// Put returns an IP address to the pool, making it available for future allocations.
-// Returns an error if the address was not previously assigned.
-func (p *IPPool) Put(addr netip.Addr) error {
+// ReserveHashed assigns an address derived from the hash of the key, so that the same key is assigned the
+// same address across restarts, as long as no other key collided with it first. On a collision with an
+// assigned or excluded address, the following addresses are probed in order, wrapping around the prefix.
+func (p *IPPool) ReserveHashed(key []byte) (netip.Addr, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	// Map the hash of the key to a block of the prefix.
+	sum := sha256.Sum256(key)
+	blocks := new(big.Int).Lsh(big.NewInt(1), uint(p.peerBits-p.prefix.Bits()))
+	index := new(big.Int).Mod(new(big.Int).SetBytes(sum[:]), blocks)
+
+	network := p.prefix.NetworkAddr()
+	offset := new(big.Int).Lsh(index, uint(network.BitLen()-p.peerBits))
+	buf := new(big.Int).Add(new(big.Int).SetBytes(network.AsSlice()), offset).FillBytes(make([]byte, network.BitLen()/8))
+
+	addr, _ := netip.AddrFromSlice(buf)
+
+	// Probe the following blocks on collisions. At most one more block than the unavailable ones is probed,
+	// and the pool is exhausted if all blocks are unavailable.
+	probes := len(p.assigned) + len(p.excluded) + 1
+	if blocks.IsInt64() && blocks.Int64() < int64(probes) {
+		probes = int(blocks.Int64())
+	}
+
+	for i := 0; i < probes; i++ {
+		if !p.assigned[addr] && !p.excluded[addr] {
+			p.removeFree(addr)
+			p.assigned[addr] = true
+
+			return addr, nil
+		}
+
+		addr = p.nextBlock(addr)
+		if !addr.IsValid() || !p.prefix.Contains(addr) {
+			addr = network
+		}
+	}
+
+	return netip.Addr{}, ErrPoolExhausted
+}
+
+// ReserveSpecific assigns the given IP address from the pool.
+// Returns an error if the address is outside the prefix or already assigned or excluded.
+func (p *IPPool) ReserveSpecific(addr netip.Addr) error {
 	p.m.Lock()
 	defer p.m.Unlock()
 
+	if !p.prefix.Contains(addr) {
+		return ErrAddrOutsidePrefix
+	}
+
+	addr = p.block(addr)
+	if p.assigned[addr] || p.excluded[addr] {
+		return ErrAddrUnavailable
+	}
+
+	p.removeFree(addr)
+	p.assigned[addr] = true
+
+	return nil
+}
+
+// Release returns an assigned IP address to the pool, making it available for future allocations.
+// Returns ErrAddrAlreadyReleased if the address was already released, or ErrAddrNotAssigned if it
+// was never assigned.
+func (p *IPPool) Release(addr netip.Addr) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	addr = p.block(addr)
+	if p.released[addr] {
+		return ErrAddrAlreadyReleased
+	}
 	if !p.assigned[addr] {
-		return errors.New("addr is not assigned")
+		return ErrAddrNotAssigned
 	}
 
-	// Remove from assigned list and add back to unassigned.
+	// Remove from assigned list and add back to the released addresses.
 	delete(p.assigned, addr)
-	p.unassigned = append(p.unassigned, addr)
+	p.released[addr] = true
+	p.free = append(p.free, addr)
 
 	return nil
 }
+
+// Available returns the number of addresses or blocks that can still be assigned, saturating at math.MaxUint64
+// for large IPv6 prefixes.
+func (p *IPPool) Available() uint64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	n := p.total()
+	n.Sub(n, big.NewInt(int64(len(p.assigned))))
+
+	return saturatingUint64(n)
+}
+
+// total returns the number of addresses or blocks of the prefix that are not excluded. The caller must hold the lock.
+func (p *IPPool) total() *big.Int {
+	// Compute the prefix size exactly, since it can exceed 64 bits for IPv6.
+	n := new(big.Int).Lsh(big.NewInt(1), uint(p.peerBits-p.prefix.Bits()))
+	return n.Sub(n, big.NewInt(int64(len(p.excluded))))
+}
+
+// saturatingUint64 returns the value as an uint64, saturating at math.MaxUint64.
+func saturatingUint64(n *big.Int) uint64 {
+	if !n.IsUint64() {
+		return math.MaxUint64
+	}
+
+	return n.Uint64()
+}
+
+// PoolStats represents the utilization of an IPPool, counting addresses or blocks.
+// Counts saturate at math.MaxUint64 for large IPv6 prefixes.
+type PoolStats struct {
+	Prefix    string `json:"prefix"`    // Prefix is the network prefix of the pool.
+	Total     uint64 `json:"total"`     // Total is the number of assignable addresses, excluding the reserved ones.
+	Allocated uint64 `json:"allocated"` // Allocated is the number of assigned addresses.
+	Available uint64 `json:"available"` // Available is the number of addresses that can still be assigned.
+}
+
+// Stats returns the number of assignable, assigned and available addresses of the pool.
+func (p *IPPool) Stats() PoolStats {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	total := p.total()
+	allocated := big.NewInt(int64(len(p.assigned)))
+
+	return PoolStats{
+		Prefix:    p.prefix.String(),
+		Total:     saturatingUint64(total),
+		Allocated: saturatingUint64(allocated),
+		Available: saturatingUint64(new(big.Int).Sub(total, allocated)),
+	}
+}
+
+// Utilization returns the fraction of assignable addresses that are assigned, between 0 and 1.
+// It returns 1 for a pool without assignable addresses, since such a pool cannot assign any.
+func (p *IPPool) Utilization() float64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	total := p.total()
+	if total.Sign() <= 0 {
+		return 1
+	}
+
+	// Divide exactly, since the total can exceed the precision of a float64 for IPv6.
+	v, _ := new(big.Rat).SetFrac(big.NewInt(int64(len(p.assigned))), total).Float64()
+	return v
+}
+
+// IPPoolSnapshot represents the persistent state of an IPPool.
+type IPPoolSnapshot struct {
+	Prefix   string   `json:"prefix"`              // Prefix is the network prefix of the pool.
+	PeerBits int      `json:"peer_bits,omitempty"` // PeerBits is the prefix length of the assigned blocks, where zero means single addresses.
+	Assigned []string `json:"assigned"`            // Assigned lists the assigned addresses in ascending order.
+	Excluded []string `json:"excluded,omitempty"`  // Excluded lists the excluded addresses in ascending order.
+}
+
+// sortedAddrs returns the addresses of the set as strings in ascending order.
+func sortedAddrs(m map[netip.Addr]bool) []string {
+	addrs := make([]netip.Addr, 0, len(m))
+	for addr := range m {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Less(addrs[j])
+	})
+
+	items := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		items = append(items, addr.String())
+	}
+
+	return items
+}
+
+// Snapshot returns the prefix and the assigned and excluded addresses of the pool, in a stable order
+// suitable for persisting as JSON. Released addresses are not recorded, since they are free to assign.
+func (p *IPPool) Snapshot() *IPPoolSnapshot {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return &IPPoolSnapshot{
+		Prefix:   p.prefix.String(),
+		PeerBits: p.peerBits,
+		Assigned: sortedAddrs(p.assigned),
+		Excluded: sortedAddrs(p.excluded),
+	}
+}
+
+// NewIPPoolFromSnapshot reconstructs an IPPool from a snapshot, marking its assigned and excluded addresses,
+// so that Reserve never returns an address that was assigned when the snapshot was taken.
+// It returns an error if any address is invalid, outside the prefix, or listed more than once.
+func NewIPPoolFromSnapshot(s *IPPoolSnapshot) (*IPPool, error) {
+	prefix, err := NewNetPrefixFromString(s.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get net prefix: %w", err)
+	}
+
+	peerBits := s.PeerBits
+	if peerBits == 0 {
+		peerBits = prefix.Addr().BitLen()
+	}
+
+	p, err := NewIPPoolWithPeerBits(prefix, peerBits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark the excluded addresses, some of which are excluded by default.
+	for _, item := range s.Excluded {
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse excluded addr: %w", err)
+		}
+		if p.excluded[addr] {
+			continue
+		}
+		if err := p.Exclude(addr); err != nil {
+			return nil, fmt.Errorf("failed to exclude addr %s: %w", addr, err)
+		}
+	}
+
+	// Mark the assigned addresses.
+	for _, item := range s.Assigned {
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse assigned addr: %w", err)
+		}
+		if err := p.ReserveSpecific(addr); err != nil {
+			return nil, fmt.Errorf("failed to reserve addr %s: %w", addr, err)
+		}
+	}
+
+	return p, nil
+}
+
+// Get fetches an available IP address from the pool.
+//
+// Deprecated: Use Reserve instead.
+func (p *IPPool) Get() (netip.Addr, error) {
+	return p.Reserve()
+}
+
+// Put returns an IP address to the pool.
+//
+// Deprecated: Use Release instead.
+func (p *IPPool) Put(addr netip.Addr) error {
+	return p.Release(addr)
+}
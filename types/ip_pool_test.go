@@ -0,0 +1,242 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestNewIPPoolUsableAddrs(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "10.5.0.1/30", want: []string{"10.5.0.2"}},
+		{prefix: "10.5.0.1/29", want: []string{"10.5.0.2", "10.5.0.3", "10.5.0.4", "10.5.0.5", "10.5.0.6"}},
+		{prefix: "10.5.0.0/31", want: nil},
+		{prefix: "10.5.0.1/31", want: nil},
+		{prefix: "10.5.0.1/32", want: nil},
+		{prefix: "fd00::1/126", want: []string{"fd00::2", "fd00::3"}},
+		{prefix: "fd00::1/127", want: nil},
+		{prefix: "fd00::1/128", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			p, err := NewIPPoolFromString(tt.prefix)
+			if err != nil {
+				t.Fatalf("NewIPPoolFromString() error = %v", err)
+			}
+
+			if got := p.Available(); got != uint64(len(tt.want)) {
+				t.Errorf("Available() = %d, want %d", got, len(tt.want))
+			}
+
+			for _, want := range tt.want {
+				addr, err := p.Reserve()
+				if err != nil {
+					t.Fatalf("Reserve() error = %v", err)
+				}
+				if addr != netip.MustParseAddr(want) {
+					t.Errorf("Reserve() = %s, want %s", addr, want)
+				}
+			}
+
+			if _, err := p.Reserve(); !errors.Is(err, ErrPoolExhausted) {
+				t.Errorf("Reserve() error = %v, want %v", err, ErrPoolExhausted)
+			}
+			if got := p.Available(); got != 0 {
+				t.Errorf("Available() after draining = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestNewIPPoolLargeIPv6(t *testing.T) {
+	p, err := NewIPPoolFromString("fd00::1/64")
+	if err != nil {
+		t.Fatalf("NewIPPoolFromString() error = %v", err)
+	}
+
+	// The prefix holds 2^64 addresses, of which the network and server addresses are excluded.
+	if got, want := p.Available(), uint64(1<<64-2); got != want {
+		t.Errorf("Available() = %d, want %d", got, want)
+	}
+
+	addr, err := p.Reserve()
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if want := netip.MustParseAddr("fd00::2"); addr != want {
+		t.Errorf("Reserve() = %s, want %s", addr, want)
+	}
+}
+
+// restoreSnapshot round-trips the snapshot of the pool through JSON and restores a pool from it.
+func restoreSnapshot(t *testing.T, p *IPPool) *IPPool {
+	t.Helper()
+
+	buf, err := json.Marshal(p.Snapshot())
+	if err != nil {
+		t.Fatalf("failed to marshal snapshot: %v", err)
+	}
+
+	var s IPPoolSnapshot
+	if err := json.Unmarshal(buf, &s); err != nil {
+		t.Fatalf("failed to unmarshal snapshot: %v", err)
+	}
+
+	restored, err := NewIPPoolFromSnapshot(&s)
+	if err != nil {
+		t.Fatalf("NewIPPoolFromSnapshot() error = %v", err)
+	}
+
+	return restored
+}
+
+func TestIPPoolSnapshotRestore(t *testing.T) {
+	p, err := NewIPPoolFromString("10.5.0.1/29")
+	if err != nil {
+		t.Fatalf("NewIPPoolFromString() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := p.Reserve(); err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+	}
+	if err := p.Release(netip.MustParseAddr("10.5.0.3")); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := p.Exclude(netip.MustParseAddr("10.5.0.6")); err != nil {
+		t.Fatalf("Exclude() error = %v", err)
+	}
+
+	s := p.Snapshot()
+	if want := []string{"10.5.0.2", "10.5.0.4"}; !reflect.DeepEqual(s.Assigned, want) {
+		t.Errorf("Assigned = %v, want %v", s.Assigned, want)
+	}
+	if want := []string{"10.5.0.0", "10.5.0.1", "10.5.0.6", "10.5.0.7"}; !reflect.DeepEqual(s.Excluded, want) {
+		t.Errorf("Excluded = %v, want %v", s.Excluded, want)
+	}
+
+	// The restored pool hands out the released and never-assigned addresses, and nothing else.
+	restored := restoreSnapshot(t, p)
+
+	var got []string
+	for {
+		addr, err := restored.Reserve()
+		if errors.Is(err, ErrPoolExhausted) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+
+		got = append(got, addr.String())
+	}
+
+	if want := []string{"10.5.0.3", "10.5.0.5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Reserve() after restore = %v, want %v", got, want)
+	}
+}
+
+func TestIPPoolSnapshotRestoreReserveHashed(t *testing.T) {
+	p, err := NewIPPoolFromString("10.5.0.1/28")
+	if err != nil {
+		t.Fatalf("NewIPPoolFromString() error = %v", err)
+	}
+
+	reserved := make(map[netip.Addr]bool)
+	for i := 0; i < 5; i++ {
+		addr, err := p.ReserveHashed([]byte{byte(i)})
+		if err != nil {
+			t.Fatalf("ReserveHashed() error = %v", err)
+		}
+
+		reserved[addr] = true
+	}
+
+	// Hashed reservations on the restored pool never collide with the restored assignments.
+	restored := restoreSnapshot(t, p)
+	for i := 0; ; i++ {
+		addr, err := restored.ReserveHashed([]byte{byte(i)})
+		if errors.Is(err, ErrPoolExhausted) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReserveHashed() error = %v", err)
+		}
+		if reserved[addr] {
+			t.Fatalf("ReserveHashed() = %s, which is already reserved", addr)
+		}
+
+		reserved[addr] = true
+	}
+
+	// The /28 has 13 usable addresses once the network, server and broadcast addresses are excluded.
+	if len(reserved) != 13 {
+		t.Errorf("reserved %d addrs, want 13", len(reserved))
+	}
+}
+
+func TestIPPoolSnapshotRestorePeerBits(t *testing.T) {
+	prefix, err := NewNetPrefixFromString("fd00::1/64")
+	if err != nil {
+		t.Fatalf("NewNetPrefixFromString() error = %v", err)
+	}
+
+	p, err := NewIPPoolWithPeerBits(prefix, 112)
+	if err != nil {
+		t.Fatalf("NewIPPoolWithPeerBits() error = %v", err)
+	}
+
+	for _, want := range []string{"fd00::1:0", "fd00::2:0"} {
+		addr, err := p.Reserve()
+		if err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+		if addr != netip.MustParseAddr(want) {
+			t.Errorf("Reserve() = %s, want %s", addr, want)
+		}
+	}
+
+	restored := restoreSnapshot(t, p)
+	if got := restored.PeerBits(); got != 112 {
+		t.Errorf("PeerBits() = %d, want 112", got)
+	}
+
+	addr, err := restored.Reserve()
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if want := netip.MustParseAddr("fd00::3:0"); addr != want {
+		t.Errorf("Reserve() after restore = %s, want %s", addr, want)
+	}
+}
+
+func TestNewIPPoolFromSnapshotInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    IPPoolSnapshot
+	}{
+		{name: "invalid prefix", s: IPPoolSnapshot{Prefix: "10.5.0.1"}},
+		{name: "invalid assigned", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Assigned: []string{"10.5.0"}}},
+		{name: "assigned outside prefix", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Assigned: []string{"10.5.0.9"}}},
+		{name: "assigned twice", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Assigned: []string{"10.5.0.2", "10.5.0.2"}}},
+		{name: "assigned and excluded", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Assigned: []string{"10.5.0.2"}, Excluded: []string{"10.5.0.2"}}},
+		{name: "assigned server addr", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Assigned: []string{"10.5.0.1"}}},
+		{name: "excluded outside prefix", s: IPPoolSnapshot{Prefix: "10.5.0.1/29", Excluded: []string{"10.6.0.1"}}},
+		{name: "invalid peer bits", s: IPPoolSnapshot{Prefix: "fd00::1/64", PeerBits: 48}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewIPPoolFromSnapshot(&tt.s); err == nil {
+				t.Errorf("NewIPPoolFromSnapshot() succeeded, want error")
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	mint "github.com/cosmos/cosmos-sdk/x/mint/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/qubetics/qubetics-blockchain/v2/crypto/ethsecp256k1"
 
 	qubeticstypes "github.com/qubetics/qubetics-blockchain/v2/types"
@@ -35,6 +37,8 @@ func NewInterfaceRegistry() codectypes.InterfaceRegistry {
 	authz.RegisterInterfaces(registry)
 	bank.RegisterInterfaces(registry)
 	feegrant.RegisterInterfaces(registry)
+	mint.RegisterInterfaces(registry)
+	slashing.RegisterInterfaces(registry)
 
 	// Register Sentinel Hub module interfaces.
 	v1.RegisterInterfaces(registry)
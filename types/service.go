@@ -2,6 +2,11 @@ package types
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // ServiceType represents the type of service as a byte.
@@ -11,8 +16,13 @@ const (
 	ServiceTypeUnspecified ServiceType = 0x00 + iota // ServiceTypeUnspecified represents an unspecified service type.
 	ServiceTypeWireGuard                             // ServiceTypeWireGuard represents the WireGuard service type.
 	ServiceTypeV2Ray                                 // ServiceTypeV2Ray represents the V2Ray service type.
+	ServiceTypeOpenVPN                               // ServiceTypeOpenVPN represents the OpenVPN service type.
 )
 
+// ErrUnsupportedServiceType is returned for service types that are known but not implemented,
+// or that are not known at all.
+var ErrUnsupportedServiceType = errors.New("unsupported service type")
+
 // String returns the string representation of the ServiceType.
 func (s ServiceType) String() string {
 	switch s {
@@ -20,18 +30,54 @@ func (s ServiceType) String() string {
 		return "wireguard"
 	case ServiceTypeV2Ray:
 		return "v2ray"
+	case ServiceTypeOpenVPN:
+		return "openvpn"
 	default:
 		return ""
 	}
 }
 
-// ServiceTypeFromString converts a string to a ServiceType.
+// IsValid returns true if the ServiceType is a known, specified service type.
+func (s ServiceType) IsValid() bool {
+	return s.String() != ""
+}
+
+// IsSupported returns true if the SDK implements clients and servers for the ServiceType.
+func (s ServiceType) IsSupported() bool {
+	return s == ServiceTypeWireGuard || s == ServiceTypeV2Ray
+}
+
+// MarshalJSON encodes the ServiceType as its string representation.
+func (s ServiceType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes the ServiceType from its string representation, rejecting unknown values.
+func (s *ServiceType) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("failed to unmarshal service type: %w", err)
+	}
+
+	t := ServiceTypeFromString(v)
+	if v != "" && t == ServiceTypeUnspecified {
+		return fmt.Errorf("%w %q", ErrUnsupportedServiceType, v)
+	}
+
+	*s = t
+	return nil
+}
+
+// ServiceTypeFromString converts a string to a ServiceType, ignoring case so that names
+// reported by nodes such as "WireGuard" or "OpenVPN" are recognized.
 func ServiceTypeFromString(s string) ServiceType {
-	switch s {
+	switch strings.ToLower(s) {
 	case "wireguard":
 		return ServiceTypeWireGuard
 	case "v2ray":
 		return ServiceTypeV2Ray
+	case "openvpn":
+		return ServiceTypeOpenVPN
 	default:
 		return ServiceTypeUnspecified
 	}
@@ -39,9 +85,13 @@ func ServiceTypeFromString(s string) ServiceType {
 
 // PeerStatistic represents the download and upload statistics for a peer.
 type PeerStatistic struct {
-	Key           string `json:"key"`            // Key is the identifier for the peer.
-	DownloadBytes int64  `json:"download_bytes"` // DownloadBytes is the total download in bytes.
-	UploadBytes   int64  `json:"upload_bytes"`   // UploadBytes is the total upload in bytes.
+	Key             string     `json:"key"`                         // Key is the identifier for the peer.
+	CollectedAt     time.Time  `json:"collected_at"`                // CollectedAt is the time the statistics were collected.
+	Connected       bool       `json:"connected,omitempty"`         // Connected indicates whether the peer is currently connected, if the service reports it.
+	DownloadBytes   int64      `json:"download_bytes"`              // DownloadBytes is the total download in bytes.
+	Endpoint        string     `json:"endpoint,omitempty"`          // Endpoint is the remote address of the peer, if known.
+	LastHandshakeAt *time.Time `json:"last_handshake_at,omitempty"` // LastHandshakeAt is the time of the latest handshake, if the service reports it.
+	UploadBytes     int64      `json:"upload_bytes"`                // UploadBytes is the total upload in bytes.
 }
 
 // ClientService defines the interface for client-side service operations.
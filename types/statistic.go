@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+)
+
+// PeerStatisticDelta represents the change in a peer's statistics between two snapshots.
+type PeerStatisticDelta struct {
+	Key           string        `json:"key"`            // Key is the identifier for the peer.
+	DownloadBytes int64         `json:"download_bytes"` // DownloadBytes is the download in bytes since the previous snapshot.
+	DownloadRate  float64       `json:"download_rate"`  // DownloadRate is the download in bytes per second over the elapsed time.
+	Elapsed       time.Duration `json:"elapsed"`        // Elapsed is the time between the two snapshots, or zero if unknown.
+	UploadBytes   int64         `json:"upload_bytes"`   // UploadBytes is the upload in bytes since the previous snapshot.
+	UploadRate    float64       `json:"upload_rate"`    // UploadRate is the upload in bytes per second over the elapsed time.
+}
+
+// CounterDelta returns the increase of a cumulative byte counter, treating a decrease as a counter reset.
+func CounterDelta(prev, curr int64) int64 {
+	if curr < prev {
+		return max(curr, 0)
+	}
+
+	return curr - prev
+}
+
+// Diff returns the per-peer byte deltas and rates between two snapshots of peer statistics.
+// Peers missing from prev are reported with their full counters and zero rates, and peers missing
+// from curr are omitted. Rates are zero when either snapshot lacks a collection time.
+func Diff(prev, curr []*PeerStatistic) []*PeerStatisticDelta {
+	// Index the previous snapshot by peer key.
+	m := make(map[string]*PeerStatistic, len(prev))
+	for _, item := range prev {
+		m[item.Key] = item
+	}
+
+	items := make([]*PeerStatisticDelta, 0, len(curr))
+	for _, item := range curr {
+		delta := &PeerStatisticDelta{
+			Key:           item.Key,
+			DownloadBytes: item.DownloadBytes,
+			UploadBytes:   item.UploadBytes,
+		}
+
+		if p, ok := m[item.Key]; ok {
+			delta.DownloadBytes = CounterDelta(p.DownloadBytes, item.DownloadBytes)
+			delta.UploadBytes = CounterDelta(p.UploadBytes, item.UploadBytes)
+
+			// Compute the rates over the time between the two collections.
+			if !p.CollectedAt.IsZero() && !item.CollectedAt.IsZero() {
+				delta.Elapsed = item.CollectedAt.Sub(p.CollectedAt)
+			}
+			if seconds := delta.Elapsed.Seconds(); seconds > 0 {
+				delta.DownloadRate = float64(delta.DownloadBytes) / seconds
+				delta.UploadRate = float64(delta.UploadBytes) / seconds
+			}
+		}
+
+		items = append(items, delta)
+	}
+
+	return items
+}
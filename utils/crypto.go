@@ -12,7 +12,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	"github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/qubetics/qubetics-blockchain/v2/crypto/ethsecp256k1"
-
 )
 
 // EncodePubKey encodes a public key to a base64-formatted string with its type.
@@ -46,7 +45,7 @@ func DecodePubKey(s string) (types.PubKey, error) {
 		return decodeEd25519Key(key)
 	case "secp256k1":
 		return decodeSecp256k1Key(key)
-	case "ethsecp256k1":
+	case "eth_secp256k1", "ethsecp256k1":
 		return decodeEthSecp256k1Key(key)
 	default:
 		return nil, errors.New("unsupported public key type")
@@ -70,7 +69,7 @@ func decodeSecp256k1Key(keyBytes []byte) (types.PubKey, error) {
 	return &secp256k1.PubKey{Key: keyBytes}, nil
 }
 
-// decodeEthSecp256k1Key validates and decodes an Ethereum-style secp256k1 public key.
+// decodeEthSecp256k1Key validates and decodes an Ethereum-style secp256k1 public key in compressed form.
 func decodeEthSecp256k1Key(keyBytes []byte) (types.PubKey, error) {
 	if len(keyBytes) != ethsecp256k1.PubKeySize {
 		return nil, fmt.Errorf("invalid ethsecp256k1 public key length: %d", len(keyBytes))
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/qubetics/qubetics-blockchain/v2/crypto/ethsecp256k1"
+)
+
+func TestPubKeyRoundTrip(t *testing.T) {
+	ethKey, err := ethsecp256k1.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  types.PrivKey
+	}{
+		{name: "ed25519", key: ed25519.GenPrivKey()},
+		{name: "secp256k1", key: secp256k1.GenPrivKey()},
+		{name: "eth_secp256k1", key: ethKey},
+	}
+
+	msg := []byte("qubetics round trip")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := tt.key.Sign(msg)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			s := EncodePubKey(tt.key.PubKey())
+
+			pubKey, err := DecodePubKey(s)
+			if err != nil {
+				t.Fatalf("DecodePubKey(%q) error = %v", s, err)
+			}
+			if pubKey.Type() != tt.key.PubKey().Type() {
+				t.Errorf("Type() = %q, want %q", pubKey.Type(), tt.key.PubKey().Type())
+			}
+			if !pubKey.Equals(tt.key.PubKey()) {
+				t.Errorf("DecodePubKey(%q) does not equal the encoded key", s)
+			}
+			if !pubKey.VerifySignature(msg, sig) {
+				t.Errorf("VerifySignature() = false, want true")
+			}
+			if pubKey.VerifySignature([]byte("tampered"), sig) {
+				t.Errorf("VerifySignature() of a different message = true, want false")
+			}
+		})
+	}
+}
+
+func TestDecodePubKeyInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "empty", s: ""},
+		{name: "missing type", s: "AAAA"},
+		{name: "extra separator", s: "ed25519:AAAA:AAAA"},
+		{name: "invalid base64", s: "ed25519:!!!"},
+		{name: "unsupported type", s: "sr25519:AAAA"},
+		{name: "short ed25519", s: "ed25519:AAAA"},
+		{name: "short secp256k1", s: "secp256k1:AAAA"},
+		{name: "short eth_secp256k1", s: "eth_secp256k1:AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodePubKey(tt.s); err == nil {
+				t.Errorf("DecodePubKey(%q) succeeded, want error", tt.s)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strconv"
@@ -39,11 +40,33 @@ func EventFromEvents(items []types.Event, t interface{}) (*types.Event, error) {
 	return nil, errors.New("event not found")
 }
 
+// attributeValue returns the value of the attribute if its key matches, decoding it from Base64 if needed.
+// Older CometBFT versions encode attribute keys and values as Base64 bytes, so the raw key is compared
+// first, and the decoded key is compared only if the raw key does not match.
+func attributeValue(attribute types.EventAttribute, key string) (string, bool) {
+	if attribute.GetKey() == key {
+		return attribute.GetValue(), true
+	}
+
+	buf, err := base64.StdEncoding.DecodeString(attribute.GetKey())
+	if err != nil || string(buf) != key {
+		return "", false
+	}
+
+	value, err := base64.StdEncoding.DecodeString(attribute.GetValue())
+	if err != nil {
+		return attribute.GetValue(), true
+	}
+
+	return string(value), true
+}
+
 // AttributeValueFromEvent searches for an attribute within an event by its key name.
+// Both plain and Base64-encoded attributes are supported, and surrounding quotes are stripped from the value.
 func AttributeValueFromEvent(item *types.Event, key string) (string, error) {
 	for _, attribute := range item.GetAttributes() {
-		if attribute.GetKey() == key {
-			return attribute.GetValue(), nil
+		if value, ok := attributeValue(attribute, key); ok {
+			return strings.Trim(value, `"`), nil
 		}
 	}
 
@@ -75,8 +98,6 @@ func IDFromEvents(items []types.Event, t interface{}) (uint64, error) {
 		return 0, fmt.Errorf("failed to get id from events: %w", err)
 	}
 
-	value = strings.Trim(value, `"`)
-
 	// Convert the ID string to uint64
 	id, err := strconv.ParseUint(value, 10, 64)
 	if err != nil {
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+)
+
+// b64 encodes s as Base64, like older CometBFT versions encode event attributes.
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func TestAttributeValueFromEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []types.EventAttribute
+		key        string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "plain",
+			attributes: []types.EventAttribute{{Key: "id", Value: "42"}},
+			key:        "id",
+			want:       "42",
+		},
+		{
+			name:       "plain quoted",
+			attributes: []types.EventAttribute{{Key: "id", Value: `"42"`}},
+			key:        "id",
+			want:       "42",
+		},
+		{
+			name:       "base64",
+			attributes: []types.EventAttribute{{Key: b64("id"), Value: b64("42")}},
+			key:        "id",
+			want:       "42",
+		},
+		{
+			name:       "base64 quoted",
+			attributes: []types.EventAttribute{{Key: b64("id"), Value: b64(`"42"`)}},
+			key:        "id",
+			want:       "42",
+		},
+		{
+			name:       "base64 key with plain value",
+			attributes: []types.EventAttribute{{Key: b64("address"), Value: "qubetics1!"}},
+			key:        "address",
+			want:       "qubetics1!",
+		},
+		{
+			name:       "plain key matching base64",
+			attributes: []types.EventAttribute{{Key: "node", Value: "addr"}},
+			key:        "node",
+			want:       "addr",
+		},
+		{
+			name: "mixed attributes",
+			attributes: []types.EventAttribute{
+				{Key: "plan_id", Value: "7"},
+				{Key: b64("id"), Value: b64("42")},
+			},
+			key:  "id",
+			want: "42",
+		},
+		{
+			name:       "empty value",
+			attributes: []types.EventAttribute{{Key: "id", Value: `""`}},
+			key:        "id",
+			want:       "",
+		},
+		{
+			name:       "not found",
+			attributes: []types.EventAttribute{{Key: "plan_id", Value: "7"}, {Key: b64("plan_id"), Value: b64("7")}},
+			key:        "id",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AttributeValueFromEvent(&types.Event{Type: "event", Attributes: tt.attributes}, tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("AttributeValueFromEvent() = %q, want error", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("AttributeValueFromEvent() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AttributeValueFromEvent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDFromEvents(t *testing.T) {
+	events := []types.Event{
+		{Type: "qubetics.node.v3.EventCreate", Attributes: []types.EventAttribute{{Key: "id", Value: `"1"`}}},
+		{Type: "qubetics.session.v3.EventStart", Attributes: []types.EventAttribute{{Key: b64("id"), Value: b64(`"42"`)}}},
+		{Type: "qubetics.plan.v3.EventCreate", Attributes: []types.EventAttribute{{Key: "id", Value: "plan"}}},
+	}
+
+	tests := []struct {
+		name    string
+		t       interface{}
+		want    uint64
+		wantErr bool
+	}{
+		{name: "plain", t: "qubetics.node.v3.EventCreate", want: 1},
+		{name: "base64", t: "qubetics.session.v3.EventStart", want: 42},
+		{name: "invalid id", t: "qubetics.plan.v3.EventCreate", wantErr: true},
+		{name: "missing event", t: "qubetics.lease.v1.EventCreate", wantErr: true},
+		{name: "unsupported type", t: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IDFromEvents(events, tt.t)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("IDFromEvents() = %d, want error", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("IDFromEvents() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IDFromEvents() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // RemoveFile deletes the file at the specified path.
@@ -16,3 +18,91 @@ func RemoveFile(path string) error {
 	// Remove the file and return the resulting error, if any.
 	return os.Remove(path)
 }
+
+// MaxSecureRemoveSize is the largest file size that RemoveFileSecure overwrites before removal.
+// Larger files are removed without being overwritten.
+const MaxSecureRemoveSize = 16 << 20
+
+// RemoveFileSecure overwrites the file at the specified path with zeros and syncs it to disk
+// before deleting it, so that secrets such as private keys are not left recoverable on disk.
+// The overwrite is best-effort: it is skipped for files larger than MaxSecureRemoveSize and
+// cannot defeat copy-on-write filesystems or SSD wear leveling.
+// It returns nil if the file does not exist or is successfully deleted.
+func RemoveFileSecure(path string) error {
+	// Check if the file exists at the given path.
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	// Overwrite regular files within the size cap before removing them.
+	if info.Mode().IsRegular() && info.Size() <= MaxSecureRemoveSize {
+		if err := overwriteFile(path, info.Size()); err != nil {
+			return fmt.Errorf("failed to overwrite file: %w", err)
+		}
+	}
+
+	// Remove the file and return the resulting error, if any.
+	return os.Remove(path)
+}
+
+// overwriteFile writes size zero bytes over the file at the specified path and syncs it to disk.
+func overwriteFile(path string, size int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	if _, err := file.WriteAt(make([]byte, size), 0); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+// WriteFileAtomic writes data to the file at the specified path with the given permissions, so that
+// readers observe either the previous content or the complete new content. The data is written to a
+// temporary file in the same directory, synced to disk and renamed over the target.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	// Create the temporary file in the target directory, so that the rename does not cross filesystems.
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	// Remove the temporary file if any of the following steps fail.
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err = file.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to change file permissions: %w", err)
+	}
+	if err = file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	// Replace the target with the complete temporary file.
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	// Sync the directory to persist the rename, ignoring platforms that do not support it.
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomicNoPartialContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	// Contents large enough to need several writes, so a non-atomic write would be observable.
+	contents := [][]byte{
+		bytes.Repeat([]byte("a"), 1<<20),
+		bytes.Repeat([]byte("b"), 1<<20+1),
+	}
+	if err := WriteFileAtomic(path, contents[0], 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			if err := WriteFileAtomic(path, contents[i%2], 0644); err != nil {
+				t.Errorf("WriteFileAtomic() error = %v", err)
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		if !bytes.Equal(data, contents[0]) && !bytes.Equal(data, contents[1]) {
+			t.Fatalf("read partial content of %d bytes", len(data))
+		}
+	}
+}
+
+func TestWriteFileAtomicPerm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	for _, perm := range []os.FileMode{0644, 0600} {
+		if err := WriteFileAtomic(path, []byte("data"), perm); err != nil {
+			t.Fatalf("WriteFileAtomic() error = %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat file: %v", err)
+		}
+		if got := info.Mode().Perm(); got != perm {
+			t.Errorf("permissions = %o, want %o", got, perm)
+		}
+	}
+}
+
+func TestWriteFileAtomicFailureKeepsTarget(t *testing.T) {
+	dir := t.TempDir()
+
+	// Renaming a file over a non-empty directory fails after the temporary file is written.
+	path := filepath.Join(dir, "config")
+	if err := os.MkdirAll(filepath.Join(path, "child"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("data"), 0644); err == nil {
+		t.Fatalf("WriteFileAtomic() over a directory succeeded")
+	}
+
+	// The target is untouched and no temporary file is left behind.
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		t.Errorf("target was modified: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1", len(entries))
+	}
+}
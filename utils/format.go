@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// byteUnits lists the binary unit suffixes used by FormatBytes, in increasing powers of 1024.
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// FormatBytes formats a byte count using binary units, for example "1.24 GiB".
+// Counts below 1024 are printed without decimals, such as "512 B".
+func FormatBytes(v int64) string {
+	sign := ""
+	n := uint64(v)
+	if v < 0 {
+		// Negate in uint64 so that math.MinInt64 does not overflow.
+		sign, n = "-", uint64(-(v+1))+1
+	}
+	if n < 1024 {
+		return fmt.Sprintf("%s%d B", sign, n)
+	}
+
+	// Find the largest unit that keeps the value at or above one.
+	f, i := float64(n), 0
+	for f >= 1024 && i < len(byteUnits)-1 {
+		f /= 1024
+		i++
+	}
+
+	return fmt.Sprintf("%s%.2f %s", sign, f, byteUnits[i])
+}
+
+// byteMultipliers maps the lower-cased unit suffixes accepted by ParseBytes to their multipliers.
+// Suffixes with an "i" are binary (powers of 1024), and the others are decimal (powers of 1000).
+var byteMultipliers = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1e6,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1e9,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"p":   1e15,
+	"pb":  1e15,
+	"pib": 1 << 50,
+	"e":   1e18,
+	"eb":  1e18,
+	"eib": 1 << 60,
+}
+
+// ParseBytes parses a byte count such as "500MB", "1.5 GiB" or "1024". Units are case-insensitive;
+// "KB", "MB", "GB" and so on are decimal, while "KiB", "MiB", "GiB" and so on are binary.
+// Fractional bytes are truncated, and values that do not fit in an int64 are rejected.
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("byte count cannot be empty")
+	}
+
+	// Split the numeric part from the unit suffix.
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	mul, ok := byteMultipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte unit %q", s[i:])
+	}
+
+	// Parse the number exactly, so that large integer counts do not lose precision.
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || strings.ContainsAny(num, "/eE") {
+		return 0, fmt.Errorf("invalid byte count %q", num)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(mul))
+
+	v := new(big.Int).Quo(r.Num(), r.Denom())
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("byte count %s overflows int64", s)
+	}
+
+	return v.Int64(), nil
+}
+
+// FormatCoin formats a coin amount in base units as a display amount, dividing it by 10^exponent and
+// appending the display denomination, for example 12500000000000000000 atics with exponent 18 as "12.5 TICS".
+// The amount is printed exactly, without rounding, and trailing zeros of the fraction are removed.
+// A coin without an amount, such as the zero value, is printed as zero.
+func FormatCoin(coin cosmossdk.DecCoin, displayDenom string, exponent uint) string {
+	if exponent > math.MaxInt32 {
+		panic(fmt.Errorf("exponent %d is too large", exponent))
+	}
+	if coin.Amount.IsNil() {
+		return fmt.Sprintf("0 %s", displayDenom)
+	}
+
+	// The decimal amount is stored as an integer scaled by 10^Precision.
+	scale := exponent + cosmossdk.Precision
+	amount := new(big.Int).Set(coin.Amount.BigInt())
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Neg(amount)
+	}
+
+	// Pad the digits so that there is at least one integer digit before the fraction.
+	digits := amount.String()
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+
+	integer, fraction := digits[:len(digits)-int(scale)], strings.TrimRight(digits[len(digits)-int(scale):], "0")
+	if fraction != "" {
+		integer += "." + fraction
+	}
+	if integer == "0" {
+		sign = ""
+	}
+
+	return fmt.Sprintf("%s%s %s", sign, integer, displayDenom)
+}
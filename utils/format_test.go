@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"math"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	cosmossdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		v    int64
+		want string
+	}{
+		{v: 0, want: "0 B"},
+		{v: 1, want: "1 B"},
+		{v: 1023, want: "1023 B"},
+		{v: 1024, want: "1.00 KiB"},
+		{v: 1536, want: "1.50 KiB"},
+		{v: 1<<20 - 1, want: "1024.00 KiB"},
+		{v: 1 << 20, want: "1.00 MiB"},
+		{v: 1331439862, want: "1.24 GiB"},
+		{v: 1 << 40, want: "1.00 TiB"},
+		{v: 1 << 50, want: "1.00 PiB"},
+		{v: 1 << 60, want: "1.00 EiB"},
+		{v: math.MaxInt64, want: "8.00 EiB"},
+		{v: -1, want: "-1 B"},
+		{v: -1023, want: "-1023 B"},
+		{v: -1536, want: "-1.50 KiB"},
+		{v: -(1 << 60), want: "-1.00 EiB"},
+		{v: -math.MaxInt64, want: "-8.00 EiB"},
+		{v: math.MinInt64, want: "-8.00 EiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.v); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    int64
+		wantErr bool
+	}{
+		{s: "0", want: 0},
+		{s: "1024", want: 1024},
+		{s: "  42  ", want: 42},
+		{s: "42B", want: 42},
+		{s: "500MB", want: 500_000_000},
+		{s: "500 mb", want: 500_000_000},
+		{s: "500M", want: 500_000_000},
+		{s: "1KiB", want: 1024},
+		{s: "1.5 GiB", want: 3 << 29},
+		{s: "1.5GB", want: 1_500_000_000},
+		{s: "0.5 B", want: 0},
+		{s: "1.9999", want: 1},
+		{s: "2TiB", want: 2 << 40},
+		{s: "1PB", want: 1e15},
+		{s: "7EiB", want: 7 << 60},
+		{s: "9223372036854775807", want: math.MaxInt64},
+		{s: "9223372036854775807B", want: math.MaxInt64},
+		{s: "-9223372036854775808", want: math.MinInt64},
+		{s: "-1KiB", want: -1024},
+		{s: "+1KB", want: 1000},
+		{s: "9223372036854775808", wantErr: true},
+		{s: "8EiB", wantErr: true},
+		{s: "9.3EB", wantErr: true},
+		{s: "", wantErr: true},
+		{s: "   ", wantErr: true},
+		{s: "MB", wantErr: true},
+		{s: "1.2.3MB", wantErr: true},
+		{s: "1e3", wantErr: true},
+		{s: "1/2", wantErr: true},
+		{s: "10 XB", wantErr: true},
+		{s: "10 MBs", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.s)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseBytes(%q) = %d, want error", tt.s, got)
+			}
+
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBytes(%q) error = %v", tt.s, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBytes(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCoin(t *testing.T) {
+	// mustDec parses a decimal amount in base units.
+	mustDec := func(s string) sdkmath.LegacyDec {
+		return sdkmath.LegacyMustNewDecFromStr(s)
+	}
+
+	tests := []struct {
+		name     string
+		coin     cosmossdk.DecCoin
+		exponent uint
+		want     string
+	}{
+		{name: "whole", coin: cosmossdk.NewDecCoinFromDec("atics", mustDec("12000000000000000000")), exponent: 18, want: "12 TICS"},
+		{name: "fraction", coin: cosmossdk.NewDecCoinFromDec("atics", mustDec("12500000000000000000")), exponent: 18, want: "12.5 TICS"},
+		{name: "smallest unit", coin: cosmossdk.NewDecCoinFromDec("atics", mustDec("1")), exponent: 18, want: "0.000000000000000001 TICS"},
+		{name: "fractional base units", coin: cosmossdk.NewDecCoinFromDec("atics", mustDec("1.5")), exponent: 18, want: "0.0000000000000000015 TICS"},
+		{name: "zero", coin: cosmossdk.NewDecCoinFromDec("atics", mustDec("0")), exponent: 18, want: "0 TICS"},
+		{name: "zero value", coin: cosmossdk.DecCoin{}, exponent: 18, want: "0 TICS"},
+		{name: "zero exponent", coin: cosmossdk.NewDecCoinFromDec("tics", mustDec("42.25")), exponent: 0, want: "42.25 TICS"},
+		{name: "negative", coin: cosmossdk.DecCoin{Denom: "atics", Amount: mustDec("-12500000000000000000")}, exponent: 18, want: "-12.5 TICS"},
+		{name: "negative fraction", coin: cosmossdk.DecCoin{Denom: "atics", Amount: mustDec("-1")}, exponent: 18, want: "-0.000000000000000001 TICS"},
+		{
+			name:     "max int64 base units",
+			coin:     cosmossdk.NewDecCoinFromDec("atics", mustDec("9223372036854775807")),
+			exponent: 18,
+			want:     "9.223372036854775807 TICS",
+		},
+		{
+			name:     "beyond int64 base units",
+			coin:     cosmossdk.NewDecCoinFromDec("atics", mustDec("123456789012345678901234567890")),
+			exponent: 18,
+			want:     "123456789012.34567890123456789 TICS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatCoin(tt.coin, "TICS", tt.exponent); got != tt.want {
+				t.Errorf("FormatCoin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
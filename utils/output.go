@@ -4,8 +4,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// Supported output formats.
+const (
+	OutputFormatJSON  = "json"
+	OutputFormatTable = "table"
+	OutputFormatText  = "text"
+	OutputFormatYAML  = "yaml"
+)
+
+// OutputFormats lists the supported output formats.
+var OutputFormats = []string{OutputFormatJSON, OutputFormatTable, OutputFormatText, OutputFormatYAML}
+
+// ValidateOutputFormat returns an error if the format is not one of the supported output formats.
+func ValidateOutputFormat(format string) error {
+	for _, item := range OutputFormats {
+		if item == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported output format %s, must be one of %s", format, strings.Join(OutputFormats, ", "))
+}
+
 // writeJSON formats the output as JSON and writes it to the provided writer.
 func writeJSON(w io.Writer, v interface{}) error {
 	buf, err := json.Marshal(v)
@@ -17,6 +40,16 @@ func writeJSON(w io.Writer, v interface{}) error {
 	return nil
 }
 
+// writeTable formats the output as a table with aligned columns and writes it to the provided writer.
+func writeTable(w io.Writer, v interface{}) error {
+	t, err := TableFromValue(v)
+	if err != nil {
+		return fmt.Errorf("failed to build table: %w", err)
+	}
+
+	return t.Write(w)
+}
+
 // writeText formats the output as YAML and writes it to the provided writer.
 func writeText(w io.Writer, v interface{}) error {
 	buf, err := YAMLFromJSON(v)
@@ -28,15 +61,30 @@ func writeText(w io.Writer, v interface{}) error {
 	return nil
 }
 
+// writeYAML formats the output as YAML, keeping the field order of the JSON encoding, and writes it to the provided writer.
+func writeYAML(w io.Writer, v interface{}) error {
+	buf, err := OrderedYAMLFromJSON(v)
+	if err != nil {
+		return fmt.Errorf("failed to convert yaml form json: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(w, "%s", buf)
+	return nil
+}
+
 // Write formats the output according to the specified format and writes it to the provided writer.
 func Write(w io.Writer, v interface{}, format string) error {
 	switch format {
-	case "json":
+	case OutputFormatJSON:
 		return writeJSON(w, v)
-	case "text":
+	case OutputFormatTable:
+		return writeTable(w, v)
+	case OutputFormatText:
 		return writeText(w, v)
+	case OutputFormatYAML:
+		return writeYAML(w, v)
 	default:
-		return fmt.Errorf("unsupported output format %s", format)
+		return ValidateOutputFormat(format)
 	}
 }
 
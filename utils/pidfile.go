@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// ErrPIDFileLocked is returned when the PID file is already locked by another instance.
+var ErrPIDFileLocked = errors.New("pid file is locked by another instance")
+
+// PIDFile manages a PID file guarded by an exclusive lock on a companion lock file,
+// so that two instances using the same path cannot overwrite each other's PID.
+// The PID file records the process start time to detect PID reuse.
+type PIDFile struct {
+	lock *os.File // Open lock file while the lock is held.
+	path string   // Path of the PID file.
+}
+
+// NewPIDFile creates a new PIDFile for the given path.
+func NewPIDFile(path string) *PIDFile {
+	return &PIDFile{
+		path: path,
+	}
+}
+
+// Path returns the path of the PID file.
+func (p *PIDFile) Path() string {
+	return p.path
+}
+
+// lockPath returns the path of the lock file.
+func (p *PIDFile) lockPath() string {
+	return p.path + ".lock"
+}
+
+// Lock acquires an exclusive lock for the PID file without blocking.
+// It returns ErrPIDFileLocked if another instance holds the lock.
+func (p *PIDFile) Lock() error {
+	if p.lock != nil {
+		return nil
+	}
+
+	file, err := os.OpenFile(p.lockPath(), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	p.lock = file
+	return nil
+}
+
+// Unlock releases the lock if it is held.
+func (p *PIDFile) Unlock() error {
+	if p.lock == nil {
+		return nil
+	}
+
+	defer func() { p.lock = nil }()
+
+	if err := unlockFile(p.lock); err != nil {
+		_ = p.lock.Close()
+		return fmt.Errorf("failed to unlock file: %w", err)
+	}
+
+	return p.lock.Close()
+}
+
+// Write records the PID and the start time of its process. The lock must be held.
+func (p *PIDFile) Write(ctx context.Context, pid int) error {
+	if p.lock == nil {
+		return errors.New("pid file is not locked")
+	}
+
+	// Retrieve the start time of the process to guard against PID reuse.
+	proc, err := process.NewProcessWithContext(ctx, int32(pid))
+	if err != nil {
+		return fmt.Errorf("failed to get process: %w", err)
+	}
+
+	createTime, err := proc.CreateTimeWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get process create time: %w", err)
+	}
+
+	data := []byte(fmt.Sprintf("%d %d", pid, createTime))
+	if err := WriteFileAtomic(p.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// Read returns the PID stored in the file. It returns zero if the file does not exist or if the
+// process with the stored PID has a different start time, meaning that the PID has been reused.
+func (p *PIDFile) Read(ctx context.Context) (int32, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, errors.New("empty pid file")
+	}
+
+	pid, err := strconv.ParseInt(fields[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse pid: %w", err)
+	}
+
+	// Files written without the start time cannot be verified.
+	if len(fields) < 2 {
+		return int32(pid), nil
+	}
+
+	createTime, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse process create time: %w", err)
+	}
+
+	// Compare the start time of the running process with the recorded one.
+	proc, err := process.NewProcessWithContext(ctx, int32(pid))
+	if err != nil {
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to get process: %w", err)
+	}
+
+	v, err := proc.CreateTimeWithContext(ctx)
+	if err != nil || v != createTime {
+		return 0, nil
+	}
+
+	return int32(pid), nil
+}
+
+// Remove deletes the PID file and releases the lock if it is held.
+func (p *PIDFile) Remove() error {
+	if err := RemoveFile(p.path); err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	if p.lock == nil {
+		return nil
+	}
+
+	// Release the lock before removing the lock file, since open files cannot be removed on Windows.
+	if err := p.Unlock(); err != nil {
+		return err
+	}
+	if err := RemoveFile(p.lockPath()); err != nil {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+
+	return nil
+}
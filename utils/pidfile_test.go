@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPIDFileDoubleStart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "service.pid")
+
+	first := NewPIDFile(path)
+	if err := first.Lock(); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if err := first.Write(ctx, os.Getpid()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// A second instance using the same path must not acquire the lock or touch the PID file.
+	second := NewPIDFile(path)
+	if err := second.Lock(); !errors.Is(err, ErrPIDFileLocked) {
+		t.Fatalf("second Lock() error = %v, want %v", err, ErrPIDFileLocked)
+	}
+	if err := second.Write(ctx, os.Getpid()); err == nil {
+		t.Errorf("second Write() without the lock succeeded")
+	}
+
+	pid, err := second.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if int(pid) != os.Getpid() {
+		t.Errorf("Read() = %d, want %d", pid, os.Getpid())
+	}
+
+	// Once the first instance is gone the second one can start.
+	if err := first.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := second.Lock(); err != nil {
+		t.Fatalf("second Lock() after Remove() error = %v", err)
+	}
+	if err := second.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+}
+
+func TestPIDFileReadReusedPID(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "service.pid")
+
+	// A recorded start time that does not match the running process means the PID was reused.
+	data := []byte(fmt.Sprintf("%d %d", os.Getpid(), 1))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write pid file: %v", err)
+	}
+
+	pid, err := NewPIDFile(path).Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if pid != 0 {
+		t.Errorf("Read() = %d, want 0", pid)
+	}
+}
+
+func TestPIDFileReadMissing(t *testing.T) {
+	pid, err := NewPIDFile(filepath.Join(t.TempDir(), "service.pid")).Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if pid != 0 {
+		t.Errorf("Read() = %d, want 0", pid)
+	}
+}
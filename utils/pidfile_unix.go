@@ -0,0 +1,28 @@
+//go:build darwin || linux
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// lockFile acquires an exclusive, non-blocking lock on the file.
+func lockFile(file *os.File) error {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return ErrPIDFileLocked
+		}
+
+		return fmt.Errorf("failed to lock file: %w", err)
+	}
+
+	return nil
+}
+
+// unlockFile releases the lock on the file.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile acquires an exclusive, non-blocking lock on the file.
+func lockFile(file *os.File) error {
+	var (
+		handle     = windows.Handle(file.Fd())
+		flags      = uint32(windows.LOCKFILE_EXCLUSIVE_LOCK | windows.LOCKFILE_FAIL_IMMEDIATELY)
+		overlapped windows.Overlapped
+	)
+
+	if err := windows.LockFileEx(handle, flags, 0, 1, 0, &overlapped); err != nil {
+		if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			return ErrPIDFileLocked
+		}
+
+		return fmt.Errorf("failed to lock file: %w", err)
+	}
+
+	return nil
+}
+
+// unlockFile releases the lock on the file.
+func unlockFile(file *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &overlapped)
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdnet "net"
+	"strconv"
+
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// IsPortAvailable reports whether the port can be bound on all interfaces for the protocol (tcp or udp).
+func IsPortAvailable(proto string, port uint16) bool {
+	addr := stdnet.JoinHostPort("", strconv.Itoa(int(port)))
+
+	switch proto {
+	case "tcp", "tcp4", "tcp6":
+		l, err := stdnet.Listen(proto, addr)
+		if err != nil {
+			return false
+		}
+
+		_ = l.Close()
+		return true
+	case "udp", "udp4", "udp6":
+		c, err := stdnet.ListenPacket(proto, addr)
+		if err != nil {
+			return false
+		}
+
+		_ = c.Close()
+		return true
+	default:
+		return false
+	}
+}
+
+// portOwner returns the PID and name of the process bound to the port for the network (tcp or udp),
+// or a zero PID if it cannot be determined, for example without the privileges to inspect other processes.
+func portOwner(ctx context.Context, network string, port uint16) (int32, string) {
+	conns, err := net.ConnectionsWithContext(ctx, network)
+	if err != nil {
+		return 0, ""
+	}
+
+	for _, conn := range conns {
+		// Skip outgoing connections, which use the port as a remote port.
+		if conn.Laddr.Port != uint32(port) || conn.Raddr.Port != 0 || conn.Pid == 0 {
+			continue
+		}
+
+		proc, err := process.NewProcessWithContext(ctx, conn.Pid)
+		if err != nil {
+			return conn.Pid, ""
+		}
+
+		name, _ := proc.NameWithContext(ctx)
+		return conn.Pid, name
+	}
+
+	return 0, ""
+}
+
+// CheckPorts checks that the TCP ports can be bound, reporting all ports in use at once along with the process
+// using each of them when it can be identified. Use CheckProtoPorts for UDP ports.
+func CheckPorts(ports ...uint16) error {
+	return CheckProtoPorts("tcp", ports...)
+}
+
+// CheckProtoPorts checks that the ports can be bound for the protocol (tcp or udp), like CheckPorts.
+func CheckProtoPorts(proto string, ports ...uint16) error {
+	var errs []error
+	for _, port := range ports {
+		if IsPortAvailable(proto, port) {
+			continue
+		}
+
+		pid, name := portOwner(context.Background(), proto, port)
+		switch {
+		case pid == 0:
+			errs = append(errs, fmt.Errorf("%s port %d already in use", proto, port))
+		case name == "":
+			errs = append(errs, fmt.Errorf("%s port %d already in use by pid %d", proto, port, pid))
+		default:
+			errs = append(errs, fmt.Errorf("%s port %d already in use by %s (pid %d)", proto, port, name, pid))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,10 +1,98 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"math/rand/v2"
 )
 
+// Port range used when picking random ports, excluding the well-known ports.
+const (
+	MinRandomPort uint16 = 1 << 10
+	MaxRandomPort uint16 = 1<<16 - 1
+)
+
+// maxFreePortAttempts is the number of random ports tried before giving up on finding a free one.
+const maxFreePortAttempts = 64
+
+// RandomPort returns a random non-privileged port without checking its availability.
 func RandomPort() uint16 {
 	n := 1<<16 - 1<<10
 	return uint16(rand.IntN(n) + 1<<10)
 }
+
+// RandomPortInRange returns a random port between lo and hi (inclusive) that is not in the exclusion list.
+// The port is not checked for availability.
+func RandomPortInRange(lo, hi uint16, exclude ...uint16) (uint16, error) {
+	if lo == 0 || lo > hi {
+		return 0, fmt.Errorf("invalid port range %d-%d", lo, hi)
+	}
+
+	excluded := make(map[uint16]bool, len(exclude))
+	for _, port := range exclude {
+		if port >= lo && port <= hi {
+			excluded[port] = true
+		}
+	}
+
+	// Pick a random offset into the ports that remain after the exclusions.
+	n := int(hi-lo) + 1 - len(excluded)
+	if n <= 0 {
+		return 0, fmt.Errorf("no ports available in range %d-%d", lo, hi)
+	}
+
+	skip := rand.IntN(n)
+	for port := int(lo); port <= int(hi); port++ {
+		if excluded[uint16(port)] {
+			continue
+		}
+		if skip == 0 {
+			return uint16(port), nil
+		}
+
+		skip--
+	}
+
+	return 0, errors.New("failed to pick a port")
+}
+
+// RandomFreePortInRange returns a random port between lo and hi (inclusive), not in the exclusion list,
+// that is confirmed to be free for the network by binding and releasing it.
+func RandomFreePortInRange(network string, lo, hi uint16, exclude ...uint16) (uint16, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return 0, fmt.Errorf("unsupported network %s", network)
+	}
+
+	tried := append([]uint16{}, exclude...)
+	for i := 0; i < maxFreePortAttempts; i++ {
+		port, err := RandomPortInRange(lo, hi, tried...)
+		if err != nil {
+			return 0, err
+		}
+		if IsPortAvailable(network, port) {
+			return port, nil
+		}
+
+		tried = append(tried, port)
+	}
+
+	return 0, fmt.Errorf("failed to find a free %s port in range %d-%d", network, lo, hi)
+}
+
+// RandomFreePort returns a random non-privileged port, not in the exclusion list,
+// that is confirmed to be free for the network (tcp or udp).
+func RandomFreePort(network string, exclude ...uint16) (uint16, error) {
+	return RandomFreePortInRange(network, MinRandomPort, MaxRandomPort, exclude...)
+}
+
+// MustRandomFreePort is like RandomFreePort but panics if no free port can be found.
+func MustRandomFreePort(network string, exclude ...uint16) uint16 {
+	port, err := RandomFreePort(network, exclude...)
+	if err != nil {
+		panic(err)
+	}
+
+	return port
+}
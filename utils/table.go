@@ -0,0 +1,251 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTableMaxWidth is the default maximum width of a table cell before it is truncated.
+const DefaultTableMaxWidth = 48
+
+// Tabler is implemented by values that declare their own table columns for the "table" output format.
+//
+// Commands declare the column set of their output in one of two ways:
+//   - by returning a value implementing Tabler, which builds the headers and rows explicitly; or
+//   - by returning a struct or a slice of structs, whose exported fields become the columns in
+//     declaration order. The column header is taken from the `table` struct tag, falling back to the
+//     name in the `json` tag and then to the field name. A `table:"-"` tag hides the column.
+type Tabler interface {
+	Table() *Table
+}
+
+// Table holds the headers and rows of a table for text output.
+type Table struct {
+	Headers  []string   // Column headers.
+	MaxWidth int        // Maximum width of a cell; longer values are truncated with an ellipsis.
+	Rows     [][]string // Cell values of each row.
+}
+
+// NewTable creates a new Table with the given headers.
+func NewTable(headers ...string) *Table {
+	return &Table{
+		Headers:  headers,
+		MaxWidth: DefaultTableMaxWidth,
+	}
+}
+
+// AddRow appends a row of cell values and returns the updated table.
+func (t *Table) AddRow(values ...string) *Table {
+	t.Rows = append(t.Rows, values)
+	return t
+}
+
+// Table implements the Tabler interface, so that a Table can be passed to Write directly.
+func (t *Table) Table() *Table {
+	return t
+}
+
+// WithMaxWidth sets the maximum width of a cell and returns the updated table.
+func (t *Table) WithMaxWidth(width int) *Table {
+	t.MaxWidth = width
+	return t
+}
+
+// truncate shortens the value to the maximum width, ending it with an ellipsis.
+func (t *Table) truncate(v string) string {
+	if t.MaxWidth <= 0 || utf8.RuneCountInString(v) <= t.MaxWidth {
+		return v
+	}
+	if t.MaxWidth == 1 {
+		return "…"
+	}
+
+	return string([]rune(v)[:t.MaxWidth-1]) + "…"
+}
+
+// isNumeric reports whether the value parses as a number.
+func isNumeric(v string) bool {
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
+
+// Write writes the table with aligned columns to the writer. Columns whose non-empty
+// values are all numeric are right-aligned. No newline is written after the last row.
+func (t *Table) Write(w io.Writer) error {
+	columns := len(t.Headers)
+	for _, row := range t.Rows {
+		columns = max(columns, len(row))
+	}
+
+	// Truncate the cells and measure the width and type of each column.
+	var (
+		cells   = make([][]string, 0, len(t.Rows)+1)
+		widths  = make([]int, columns)
+		numeric = make([]bool, columns)
+	)
+	for i := range numeric {
+		numeric[i] = true
+	}
+
+	for r, row := range append([][]string{t.Headers}, t.Rows...) {
+		line := make([]string, columns)
+		for i := 0; i < columns; i++ {
+			if i < len(row) {
+				line[i] = t.truncate(row[i])
+			}
+
+			widths[i] = max(widths[i], utf8.RuneCountInString(line[i]))
+			if r > 0 && line[i] != "" && !isNumeric(line[i]) {
+				numeric[i] = false
+			}
+		}
+
+		cells = append(cells, line)
+	}
+
+	// Pad each cell to the column width, separating columns with two spaces.
+	lines := make([]string, 0, len(cells))
+	for _, line := range cells {
+		parts := make([]string, columns)
+		for i, v := range line {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v))
+			if numeric[i] {
+				parts[i] = pad + v
+			} else {
+				parts[i] = v + pad
+			}
+		}
+
+		lines = append(lines, strings.TrimRight(strings.Join(parts, "  "), " "))
+	}
+
+	if _, err := io.WriteString(w, strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+
+	return nil
+}
+
+// tableColumn describes a struct field shown as a table column.
+type tableColumn struct {
+	header string
+	index  int
+}
+
+// tableColumns returns the columns of the struct type based on its field tags.
+func tableColumns(t reflect.Type) []tableColumn {
+	var columns []tableColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		header := field.Name
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+			header = name
+		}
+		if name := field.Tag.Get("table"); name != "" {
+			header = name
+		}
+		if header == "-" {
+			continue
+		}
+
+		columns = append(columns, tableColumn{header: strings.ToUpper(header), index: i})
+	}
+
+	return columns
+}
+
+// tableCell formats a field value as a table cell; composite values are encoded as JSON.
+func tableCell(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+
+		v = v.Elem()
+	}
+
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		buf, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+
+		return string(buf)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// TableFromValue builds a table from a Tabler, a struct, or a slice of structs.
+func TableFromValue(v interface{}) (*Table, error) {
+	if t, ok := v.(Tabler); ok {
+		return t.Table(), nil
+	}
+
+	// Collect the rows, treating a single struct as a table with one row.
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("nil value")
+		}
+
+		rv = rv.Elem()
+	}
+
+	var items []reflect.Value
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, reflect.Indirect(rv.Index(i)))
+		}
+	default:
+		items = append(items, rv)
+	}
+
+	// Determine the columns from the element type.
+	elem := rv.Type()
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		elem = elem.Elem()
+	}
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported table value %s", elem)
+	}
+
+	columns := tableColumns(elem)
+
+	t := NewTable()
+	for _, column := range columns {
+		t.Headers = append(t.Headers, column.header)
+	}
+
+	for _, item := range items {
+		row := make([]string, len(columns))
+		if item.IsValid() {
+			for i, column := range columns {
+				row[i] = tableCell(item.Field(column.index))
+			}
+		}
+
+		t.AddRow(row...)
+	}
+
+	return t, nil
+}
@@ -2,33 +2,127 @@ package utils
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"math/big"
+	"net/netip"
 	"os"
+	"reflect"
 	"strings"
 	"text/template"
 )
 
 var funcMap = template.FuncMap{
-	"join": strings.Join,
-	"sum":  func(x, y int) int { return x + y },
+	"b64enc":   func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"cidrHost": cidrHost,
+	"default":  defaultValue,
+	"join":     strings.Join,
+	"lower":    strings.ToLower,
+	"sum":      func(x, y int) int { return x + y },
 }
 
-// ExecTemplateToFile generates content from a template and writes it to a file.
-func ExecTemplateToFile(text string, data interface{}, fileName string) error {
+// TemplateFuncMap returns a copy of the standard functions available to templates.
+func TemplateFuncMap() template.FuncMap {
+	m := make(template.FuncMap, len(funcMap))
+	for k, v := range funcMap {
+		m[k] = v
+	}
+
+	return m
+}
+
+// defaultValue returns def if v is empty (nil or the zero value of its type), otherwise v.
+// Arguments are ordered for use in pipelines, e.g. {{ .Port | default 443 }}.
+func defaultValue(def, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		if rv.Len() == 0 {
+			return def
+		}
+	default:
+		if rv.IsZero() {
+			return def
+		}
+	}
+
+	return v
+}
+
+// cidrHost returns the address of the nth host in the network prefix, such as 10.0.0.1 for ("10.0.0.0/24", 1).
+func cidrHost(prefix string, n int) (string, error) {
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix: %w", err)
+	}
+	if n < 0 {
+		return "", fmt.Errorf("host number %d cannot be negative", n)
+	}
+
+	// Add the host number to the network address and check that it stays within the prefix.
+	base := p.Masked().Addr()
+	v := new(big.Int).SetBytes(base.AsSlice())
+	v.Add(v, big.NewInt(int64(n)))
+
+	buf := v.Bytes()
+	if len(buf) > base.BitLen()/8 {
+		return "", fmt.Errorf("host number %d exceeds prefix %s", n, prefix)
+	}
+
+	raw := make([]byte, base.BitLen()/8)
+	copy(raw[len(raw)-len(buf):], buf)
+
+	addr, _ := netip.AddrFromSlice(raw)
+	if !p.Contains(addr) {
+		return "", fmt.Errorf("host number %d exceeds prefix %s", n, prefix)
+	}
+
+	return addr.String(), nil
+}
+
+// ExecTemplate generates content from a template using the standard functions and any additional ones.
+// Additional functions override standard functions with the same name.
+func ExecTemplate(text string, data interface{}, funcs template.FuncMap) ([]byte, error) {
 	// Parse the template with custom functions
-	tmpl, err := template.New("config").Funcs(funcMap).Parse(text)
+	tmpl, err := template.New("config").Funcs(funcMap).Funcs(funcs).Parse(text)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
 
 	// Execute the template and capture the output
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExecTemplateToFile generates content from a template and atomically writes it to a file.
+// The file permissions default to 0644 unless a mode is given.
+func ExecTemplateToFile(text string, data interface{}, fileName string, mode ...os.FileMode) error {
+	return ExecTemplateToFileWithFuncs(text, data, fileName, nil, mode...)
+}
+
+// ExecTemplateToFileWithFuncs is like ExecTemplateToFile but makes additional functions available to the template.
+func ExecTemplateToFileWithFuncs(text string, data interface{}, fileName string, funcs template.FuncMap, mode ...os.FileMode) error {
+	buf, err := ExecTemplate(text, data, funcs)
+	if err != nil {
+		return err
+	}
+
+	// Determine the file permissions
+	perm := os.FileMode(0644)
+	if len(mode) > 0 {
+		perm = mode[0]
 	}
 
 	// Write the generated content to the specified file
-	if err := os.WriteFile(fileName, buf.Bytes(), 0644); err != nil {
+	if err := WriteFileAtomic(fileName, buf, perm); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestExecTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		data    interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "b64enc", text: `{{ b64enc "hello" }}`, want: "aGVsbG8="},
+		{name: "b64enc empty", text: `{{ b64enc "" }}`, want: ""},
+		{name: "cidrHost ipv4", text: `{{ cidrHost "10.0.0.0/24" 1 }}`, want: "10.0.0.1"},
+		{name: "cidrHost unmasked prefix", text: `{{ cidrHost "10.0.0.5/24" 255 }}`, want: "10.0.0.255"},
+		{name: "cidrHost ipv6", text: `{{ cidrHost "fd00::/64" 1 }}`, want: "fd00::1"},
+		{name: "cidrHost network address", text: `{{ cidrHost "10.0.0.0/24" 0 }}`, want: "10.0.0.0"},
+		{name: "cidrHost beyond prefix", text: `{{ cidrHost "10.0.0.0/24" 256 }}`, wantErr: true},
+		{name: "cidrHost beyond address space", text: `{{ cidrHost "255.255.255.0/24" 256 }}`, wantErr: true},
+		{name: "cidrHost negative", text: `{{ cidrHost "10.0.0.0/24" -1 }}`, wantErr: true},
+		{name: "cidrHost invalid prefix", text: `{{ cidrHost "10.0.0.0" 1 }}`, wantErr: true},
+		{name: "default zero int", text: `{{ .Port | default 443 }}`, data: map[string]interface{}{"Port": 0}, want: "443"},
+		{name: "default set int", text: `{{ .Port | default 443 }}`, data: map[string]interface{}{"Port": 8443}, want: "8443"},
+		{name: "default empty string", text: `{{ .Name | default "node" }}`, data: map[string]interface{}{"Name": ""}, want: "node"},
+		{name: "default missing key", text: `{{ .Name | default "node" }}`, data: map[string]interface{}{}, want: "node"},
+		{name: "default empty slice", text: `{{ .List | default "none" }}`, data: map[string]interface{}{"List": []string{}}, want: "none"},
+		{name: "default false", text: `{{ .On | default true }}`, data: map[string]interface{}{"On": false}, want: "true"},
+		{name: "join", text: `{{ join .List ", " }}`, data: map[string]interface{}{"List": []string{"a", "b", "c"}}, want: "a, b, c"},
+		{name: "join empty", text: `{{ join .List ", " }}`, data: map[string]interface{}{"List": []string{}}, want: ""},
+		{name: "lower", text: `{{ lower "Node.Example.COM" }}`, want: "node.example.com"},
+		{name: "sum", text: `{{ sum 1 2 }}`, want: "3"},
+		{name: "sum negative", text: `{{ sum 1 -2 }}`, want: "-1"},
+		{name: "unknown function", text: `{{ upper "a" }}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExecTemplate(tt.text, tt.data, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExecTemplate(%q) = %q, want error", tt.text, got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecTemplate(%q) error = %v", tt.text, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ExecTemplate(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecTemplateAdditionalFuncs(t *testing.T) {
+	funcs := template.FuncMap{
+		"lower": strings.ToUpper,
+		"upper": strings.ToUpper,
+	}
+
+	// Additional functions are available and override standard functions with the same name.
+	got, err := ExecTemplate(`{{ upper "a" }} {{ lower "b" }} {{ sum 1 2 }}`, nil, funcs)
+	if err != nil {
+		t.Fatalf("ExecTemplate() error = %v", err)
+	}
+	if want := "A B 3"; string(got) != want {
+		t.Errorf("ExecTemplate() = %q, want %q", got, want)
+	}
+
+	// The standard functions are not modified by additional ones.
+	if got, err := ExecTemplate(`{{ lower "B" }}`, nil, nil); err != nil || string(got) != "b" {
+		t.Errorf("ExecTemplate() = %q, %v, want %q", got, err, "b")
+	}
+}
+
+func TestTemplateFuncMapIsCopy(t *testing.T) {
+	m := TemplateFuncMap()
+	for _, name := range []string{"b64enc", "cidrHost", "default", "join", "lower", "sum"} {
+		if _, ok := m[name]; !ok {
+			t.Errorf("TemplateFuncMap() is missing %q", name)
+		}
+	}
+
+	delete(m, "lower")
+	if _, ok := TemplateFuncMap()["lower"]; !ok {
+		t.Errorf("modifying the returned map changed the standard functions")
+	}
+}
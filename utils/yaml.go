@@ -42,3 +42,36 @@ func YAMLFromJSON(i interface{}) ([]byte, error) {
 
 	return buf, nil
 }
+
+// OrderedYAMLFromJSON converts an input to YAML format, keeping the key order of its JSON encoding.
+// For structs, fields appear in the order of their declaration rather than sorted alphabetically.
+func OrderedYAMLFromJSON(i interface{}) ([]byte, error) {
+	buf, err := json.Marshal(i)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	// JSON is valid YAML, so decoding into a node preserves the order of mapping keys
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf, &node); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+
+	// Reset the flow and quoting styles inherited from JSON to get block style output
+	resetYAMLStyle(&node)
+
+	buf, err = yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal yaml: %w", err)
+	}
+
+	return buf, nil
+}
+
+// resetYAMLStyle clears the style of the node and all of its children.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
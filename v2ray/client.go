@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"net"
 	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/shirou/gopsutil/v4/process"
+	statscommand "github.com/v2fly/v2ray-core/v5/app/stats/command"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -21,9 +26,12 @@ var _ types.ClientService = (*Client)(nil)
 
 // Client represents a V2Ray client with associated command, home directory, and name.
 type Client struct {
-	cmd     *exec.Cmd // Command for running the V2Ray client.
-	homeDir string    // Home directory for client files.
-	name    string    // Name of the interface.
+	apiPort      uint16         // Port of the API inbound serving the stats service.
+	cmd          *exec.Cmd      // Command for running the V2Ray client.
+	homeDir      string         // Home directory for client files.
+	name         string         // Name of the interface.
+	pid          *utils.PIDFile // PID file of the running client process.
+	secureRemove bool           // Overwrite the configuration file before removing it.
 }
 
 // NewClient creates a new Client instance.
@@ -43,6 +51,22 @@ func (c *Client) WithName(name string) *Client {
 	return c
 }
 
+// WithSecureRemove sets whether the configuration file, which contains the user ID, is overwritten
+// before it is removed in PostDown, and returns the updated Client instance.
+func (c *Client) WithSecureRemove(secureRemove bool) *Client {
+	c.secureRemove = secureRemove
+	return c
+}
+
+// removeFile removes the file at the specified path, overwriting it first if secure removal is enabled.
+func (c *Client) removeFile(path string) error {
+	if c.secureRemove {
+		return utils.RemoveFileSecure(path)
+	}
+
+	return utils.RemoveFile(path)
+}
+
 // configFilePath returns the file path of the client's configuration file.
 func (c *Client) configFilePath() string {
 	return filepath.Join(c.homeDir, fmt.Sprintf("%s.json", c.name))
@@ -53,39 +77,13 @@ func (c *Client) pidFilePath() string {
 	return filepath.Join(c.homeDir, fmt.Sprintf("%s.pid", c.name))
 }
 
-// readPIDFromFile reads the PID from the client's PID file.
-func (c *Client) readPIDFromFile() (int32, error) {
-	name := c.pidFilePath()
-	if _, err := os.Stat(name); os.IsNotExist(err) {
-		return 0, nil
-	}
-
-	// Read PID from the PID file.
-	data, err := os.ReadFile(name)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	// Convert PID data to integer.
-	pid, err := strconv.ParseInt(string(data), 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse pid: %w", err)
-	}
-
-	return int32(pid), nil
-}
-
-// writePIDToFile writes the given PID to the client's PID file.
-func (c *Client) writePIDToFile(pid int) error {
-	// Convert PID to byte slice.
-	data := []byte(strconv.Itoa(pid))
-
-	// Write PID to file with appropriate permissions.
-	if err := os.WriteFile(c.pidFilePath(), data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// pidFile returns the PID file of the client, creating it on first use.
+func (c *Client) pidFile() *utils.PIDFile {
+	if c.pid == nil {
+		c.pid = utils.NewPIDFile(c.pidFilePath())
 	}
 
-	return nil
+	return c.pid
 }
 
 // Type returns the service type of the client.
@@ -96,7 +94,7 @@ func (c *Client) Type() types.ServiceType {
 // IsUp checks if the V2Ray client process is running.
 func (c *Client) IsUp(ctx context.Context) (bool, error) {
 	// Read PID from file.
-	pid, err := c.readPIDFromFile()
+	pid, err := c.pidFile().Read(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to read pid from file: %w", err)
 	}
@@ -150,11 +148,19 @@ func (c *Client) PreUp(v interface{}) error {
 		return fmt.Errorf("failed to write config to file: %w", err)
 	}
 
+	// Keep the API port for querying the traffic statistics.
+	c.apiPort = cfg.API.Port
+
 	return nil
 }
 
 // Up starts the V2Ray client process.
 func (c *Client) Up(ctx context.Context) error {
+	// Lock the PID file to prevent another instance from using the same name.
+	if err := c.pidFile().Lock(); err != nil {
+		return fmt.Errorf("failed to lock pid file: %w", err)
+	}
+
 	// Constructs the command to start the V2Ray client.
 	c.cmd = exec.CommandContext(
 		ctx,
@@ -164,6 +170,7 @@ func (c *Client) Up(ctx context.Context) error {
 
 	// Starts the V2Ray client process.
 	if err := c.cmd.Start(); err != nil {
+		_ = c.pidFile().Unlock()
 		return fmt.Errorf("failed to start command: %w", err)
 	}
 
@@ -178,7 +185,7 @@ func (c *Client) PostUp() error {
 	}
 
 	// Write PID to file.
-	if err := c.writePIDToFile(c.cmd.Process.Pid); err != nil {
+	if err := c.pidFile().Write(context.Background(), c.cmd.Process.Pid); err != nil {
 		return fmt.Errorf("failed to write pid to file: %w", err)
 	}
 
@@ -197,10 +204,13 @@ func (c *Client) PreDown() error {
 // Down terminates the V2Ray client process.
 func (c *Client) Down(ctx context.Context) error {
 	// Read PID from file.
-	pid, err := c.readPIDFromFile()
+	pid, err := c.pidFile().Read(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to read pid from file: %w", err)
 	}
+	if pid == 0 {
+		return nil
+	}
 
 	// Retrieve process with the given PID.
 	proc, err := process.NewProcessWithContext(ctx, pid)
@@ -223,19 +233,80 @@ func (c *Client) Down(ctx context.Context) error {
 // PostDown performs cleanup operations after the client process is terminated.
 func (c *Client) PostDown() error {
 	// Removes configuration file.
-	if err := utils.RemoveFile(c.configFilePath()); err != nil {
+	if err := c.removeFile(c.configFilePath()); err != nil {
 		return fmt.Errorf("failed to remove file: %w", err)
 	}
 
 	// Removes PID file.
-	if err := utils.RemoveFile(c.pidFilePath()); err != nil {
+	if err := c.pidFile().Remove(); err != nil {
 		return fmt.Errorf("failed to remove file: %w", err)
 	}
 
 	return nil
 }
 
-// Statistics returns dummy statistics for now (to be implemented).
-func (c *Client) Statistics(_ context.Context) (int64, int64, error) {
-	return 0, 0, nil
+// statsServiceClient establishes a gRPC client connection to the stats service of the V2Ray client.
+func (c *Client) statsServiceClient() (*grpc.ClientConn, statscommand.StatsServiceClient, error) {
+	target := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(c.apiPort)))
+
+	conn, err := grpc.NewClient(
+		target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+
+	return conn, statscommand.NewStatsServiceClient(conn), nil
+}
+
+// Statistics returns the bytes uploaded and downloaded through the outbounds of the V2Ray client, as
+// reported by its stats service. Zero is returned if the stats service is unreachable, such as when the
+// client is not running.
+func (c *Client) Statistics(ctx context.Context) (upload int64, download int64, err error) {
+	if c.apiPort == 0 {
+		return 0, 0, nil
+	}
+
+	// Establish a gRPC client connection to the stats service.
+	conn, client, err := c.statsServiceClient()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get stats service client: %w", err)
+	}
+
+	defer func() {
+		if cerr := conn.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close grpc client connection: %w", cerr)
+		}
+	}()
+
+	// Query the traffic counters of all outbounds.
+	res, err := client.QueryStats(ctx, &statscommand.QueryStatsRequest{
+		Patterns: []string{"outbound>>>"},
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return 0, 0, nil
+		}
+
+		return 0, 0, fmt.Errorf("failed to query stats: %w", err)
+	}
+
+	// Sum the counters, which are named "outbound>>>{tag}>>>traffic>>>{uplink|downlink}", skipping the
+	// internal API outbound.
+	for _, stat := range res.GetStat() {
+		parts := strings.Split(stat.GetName(), ">>>")
+		if len(parts) != 4 || parts[1] == "api" {
+			continue
+		}
+
+		switch parts[3] {
+		case "uplink":
+			upload += stat.GetValue()
+		case "downlink":
+			download += stat.GetValue()
+		}
+	}
+
+	return upload, download, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/v2fly/v2ray-core/v5/common/uuid"
 
+	"github.com/qubetics/qubetics-go-sdk/config"
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
 )
@@ -29,7 +30,7 @@ func (c *APIClientConfig) Validate() error {
 // DefaultAPIClientConfig creates a default API client configuration.
 func DefaultAPIClientConfig() *APIClientConfig {
 	return &APIClientConfig{
-		Port: utils.RandomPort(),
+		Port: utils.MustRandomFreePort("tcp"),
 	}
 }
 
@@ -108,7 +109,7 @@ func (c *ProxyClientConfig) Validate() error {
 // DefaultProxyClientConfig creates a default ProxyClientConfig.
 func DefaultProxyClientConfig() *ProxyClientConfig {
 	return &ProxyClientConfig{
-		Port: utils.RandomPort(),
+		Port: utils.MustRandomFreePort("tcp"),
 	}
 }
 
@@ -122,6 +123,24 @@ type ClientConfig struct {
 	Proxy     *ProxyClientConfig      `mapstructure:"proxy"`
 }
 
+// Redacted returns a copy of the configuration with the user ID, which authenticates the client, masked.
+func (c *ClientConfig) Redacted() *ClientConfig {
+	v := *c
+	v.ID = config.RedactString(v.ID)
+
+	return &v
+}
+
+// RedactedSection returns the redacted configuration as a config.Section.
+func (c *ClientConfig) RedactedSection() config.Section {
+	return c.Redacted()
+}
+
+// String returns the redacted configuration encoded as JSON, so the user ID never appears in logs.
+func (c *ClientConfig) String() string {
+	return string(utils.MustMarshalJSON(c.Redacted()))
+}
+
 func (c *ClientConfig) GetID() uuid.UUID {
 	id, err := uuid.ParseString(c.ID)
 	if err != nil {
@@ -177,7 +196,7 @@ func (c *ClientConfig) WriteToFile(name string) error {
 	}
 
 	// Execute the template and write it to the specified file.
-	if err := utils.ExecTemplateToFile(string(text), c, name); err != nil {
+	if err := utils.ExecTemplateToFile(string(text), c, name, 0600); err != nil {
 		return fmt.Errorf("failed to execute template to file: %w", err)
 	}
 
@@ -193,12 +212,18 @@ func (c *ClientConfig) SetForFlags(f *pflag.FlagSet) {
 
 // DefaultClientConfig creates a default ClientConfig with predefined values.
 func DefaultClientConfig() *ClientConfig {
+	// The proxy must not listen on the same port as the API.
+	api := DefaultAPIClientConfig()
+	proxy := &ProxyClientConfig{
+		Port: utils.MustRandomFreePort("tcp", api.Port),
+	}
+
 	return &ClientConfig{
 		Addr:      "",
-		API:       DefaultAPIClientConfig(),
+		API:       api,
 		ID:        NewStringUUID(),
 		Name:      "v2ray",
 		Outbounds: []*OutboundClientConfig{},
-		Proxy:     DefaultProxyClientConfig(),
+		Proxy:     proxy,
 	}
 }
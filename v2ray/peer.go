@@ -7,6 +7,7 @@ import (
 // Peer represents an entity with an Email field.
 type Peer struct {
 	Email string // Email uniquely identifies the Peer
+	UUID  string // Base64-encoded UUID of the Peer
 }
 
 // Key returns the unique identifier (email) associated with the Peer.
@@ -16,8 +17,9 @@ func (p *Peer) Key() string {
 
 // PeerManager is a thread-safe map-like structure that stores Peer objects.
 type PeerManager struct {
-	*sync.RWMutex                  // Read-write mutex for safe concurrent access
-	m             map[string]*Peer // Map storing Peers indexed by their keys
+	*sync.RWMutex                   // Read-write mutex for safe concurrent access
+	m             map[string]*Peer  // Map storing Peers indexed by their keys
+	emails        map[string]string // Map storing the emails of Peers indexed by their UUIDs
 }
 
 // NewPeerManager creates and returns a new instance of PeerManager.
@@ -25,6 +27,7 @@ func NewPeerManager() *PeerManager {
 	return &PeerManager{
 		RWMutex: &sync.RWMutex{},
 		m:       make(map[string]*Peer),
+		emails:  make(map[string]string),
 	}
 }
 
@@ -42,26 +45,52 @@ func (pm *PeerManager) Get(v string) *Peer {
 	return value
 }
 
+// Email returns the email of the Peer with the provided base64-encoded UUID.
+// It returns false if no such Peer exists.
+func (pm *PeerManager) Email(uuid string) (string, bool) {
+	pm.RLock()
+	defer pm.RUnlock()
+
+	email, ok := pm.emails[uuid]
+	return email, ok
+}
+
 // Put adds a Peer to the PeerManager.
-// If a Peer with the same key already exists, it does nothing.
-func (pm *PeerManager) Put(v *Peer) {
+// If a Peer with the same key or UUID already exists, it does nothing and returns false.
+func (pm *PeerManager) Put(v *Peer) bool {
 	pm.Lock()
 	defer pm.Unlock()
 
-	_, ok := pm.m[v.Key()]
-	if ok {
-		return
+	if _, ok := pm.m[v.Key()]; ok {
+		return false
+	}
+	if v.UUID != "" {
+		if _, ok := pm.emails[v.UUID]; ok {
+			return false
+		}
+
+		pm.emails[v.UUID] = v.Email
 	}
 
 	pm.m[v.Key()] = v
+	return true
 }
 
 // Delete removes a Peer from the PeerManager based on the provided key.
-func (pm *PeerManager) Delete(v string) {
+// It returns false if no such Peer exists.
+func (pm *PeerManager) Delete(v string) bool {
 	pm.Lock()
 	defer pm.Unlock()
 
+	value, ok := pm.m[v]
+	if !ok {
+		return false
+	}
+
+	delete(pm.emails, value.UUID)
 	delete(pm.m, v)
+
+	return true
 }
 
 // Len returns the number of Peers in the PeerManager.
@@ -2,13 +2,56 @@ package v2ray
 
 import (
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/v2fly/v2ray-core/v5/common/uuid"
 )
 
+// maxIdentifierLen is the maximum length of a peer identifier.
+const maxIdentifierLen = 256
+
+// peerEmail returns the identifier if it is set, otherwise the key.
+func peerEmail(identifier, key string) string {
+	if identifier != "" {
+		return identifier
+	}
+
+	return key
+}
+
+// validateIdentifier checks that a peer identifier, if set, can be used as the email of a V2Ray user.
+// Since the traffic counters of a user are named "user>>>{email}>>>traffic>>>{uplink|downlink}", the
+// identifier must not contain the ">>>" separator.
+func validateIdentifier(identifier string) error {
+	if identifier == "" {
+		return nil
+	}
+	if len(identifier) > maxIdentifierLen {
+		return fmt.Errorf("identifier length cannot exceed %d", maxIdentifierLen)
+	}
+	if strings.Contains(identifier, ">>>") {
+		return errors.New("identifier cannot contain \">>>\"")
+	}
+	if strings.IndexFunc(identifier, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return errors.New("identifier cannot contain whitespace or control characters")
+	}
+
+	return nil
+}
+
 // AddPeerRequest represents a request to add a peer.
+//
+// The peer is added to V2Ray as a user whose email is the identifier of the request, or the key if the
+// identifier is empty. The email names the traffic counters of the user, and is the key of the statistics
+// returned by PeerStatistics, so an identifier such as "session-42.{key}" lets billing match the statistics
+// with sessions. HasPeerRequest and RemovePeerRequest resolve the identifier from the key, so they
+// need not repeat it.
 type AddPeerRequest struct {
-	UUID uuid.UUID `json:"uuid"`
+	Identifier string    `json:"identifier,omitempty"` // Identifier of the peer in V2Ray, if not the key. See Email.
+	UUID       uuid.UUID `json:"uuid"`
 }
 
 // Bytes returns the byte representation of the UUID.
@@ -22,9 +65,15 @@ func (r *AddPeerRequest) Key() string {
 	return base64.StdEncoding.EncodeToString(buf)
 }
 
+// Email returns the identifier of the peer in V2Ray, which is the identifier of the request if set,
+// otherwise its key.
+func (r *AddPeerRequest) Email() string {
+	return peerEmail(r.Identifier, r.Key())
+}
+
 // Validate ensures the request is valid.
 func (r *AddPeerRequest) Validate() error {
-	return nil
+	return validateIdentifier(r.Identifier)
 }
 
 // NewAddPeerRequestFromBytes creates an AddPeerRequest from bytes.
@@ -51,7 +100,8 @@ func NewAddPeerRequestFromKey(s string) (*AddPeerRequest, error) {
 
 // HasPeerRequest represents a request to check if a peer exists.
 type HasPeerRequest struct {
-	UUID uuid.UUID `json:"uuid"`
+	Identifier string    `json:"identifier,omitempty"` // Identifier the peer was added with, checked if set.
+	UUID       uuid.UUID `json:"uuid"`
 }
 
 // Bytes returns the byte representation of the UUID.
@@ -65,9 +115,15 @@ func (r *HasPeerRequest) Key() string {
 	return base64.StdEncoding.EncodeToString(buf)
 }
 
+// Email returns the identifier of the request if set, otherwise its key. The server resolves the
+// identifier from the key instead, and only checks it against this one if set.
+func (r *HasPeerRequest) Email() string {
+	return peerEmail(r.Identifier, r.Key())
+}
+
 // Validate ensures the request is valid.
 func (r *HasPeerRequest) Validate() error {
-	return nil
+	return validateIdentifier(r.Identifier)
 }
 
 // NewHasPeerRequestFromBytes creates a HasPeerRequest from bytes.
@@ -94,7 +150,8 @@ func NewHasPeerRequestFromKey(s string) (*HasPeerRequest, error) {
 
 // RemovePeerRequest represents a request to remove a peer.
 type RemovePeerRequest struct {
-	UUID uuid.UUID `json:"uuid"`
+	Identifier string    `json:"identifier,omitempty"` // Identifier the peer was added with, checked if set.
+	UUID       uuid.UUID `json:"uuid"`
 }
 
 // Bytes returns the byte representation of the UUID.
@@ -108,9 +165,15 @@ func (r *RemovePeerRequest) Key() string {
 	return base64.StdEncoding.EncodeToString(buf)
 }
 
+// Email returns the identifier of the request if set, otherwise its key. The server resolves the
+// identifier from the key instead, and only checks it against this one if set.
+func (r *RemovePeerRequest) Email() string {
+	return peerEmail(r.Identifier, r.Key())
+}
+
 // Validate ensures the request is valid.
 func (r *RemovePeerRequest) Validate() error {
-	return nil
+	return validateIdentifier(r.Identifier)
 }
 
 // NewRemovePeerRequestFromBytes creates a RemovePeerRequest from bytes.
@@ -4,11 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/process"
 	proxymancommand "github.com/v2fly/v2ray-core/v5/app/proxyman/command"
@@ -16,7 +15,9 @@ import (
 	"github.com/v2fly/v2ray-core/v5/common/protocol"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -27,16 +28,26 @@ var _ types.ServerService = (*Server)(nil)
 
 // Server represents the V2Ray server instance.
 type Server struct {
-	cmd      *exec.Cmd         // Command to run the V2Ray server.
-	homeDir  string            // Home directory of the V2Ray server.
-	metadata []*ServerMetadata // Metadata for server's inbound connections.
-	name     string            // Name of the server instance.
-	pm       *PeerManager      // Peer manager for handling peer information.
+	cmd             *exec.Cmd         // Command to run the V2Ray server.
+	homeDir         string            // Home directory of the V2Ray server.
+	metadata        []*ServerMetadata // Metadata for server's inbound connections.
+	name            string            // Name of the server instance.
+	pid             *utils.PIDFile    // PID file of the running server process.
+	pm              *PeerManager      // Peer manager for handling peer information.
+	shutdownTimeout time.Duration     // Time to wait for the process to exit before killing it.
 }
 
+// DefaultShutdownTimeout is the default time Down waits for the server process to exit after terminating it.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// shutdownPollInterval is the interval at which Down checks whether the server process has exited.
+const shutdownPollInterval = 100 * time.Millisecond
+
 // NewServer creates a new Server instance.
 func NewServer() *Server {
-	return &Server{}
+	return &Server{
+		shutdownTimeout: DefaultShutdownTimeout,
+	}
 }
 
 // WithHomeDir sets the home directory for the server and returns the updated Server instance.
@@ -57,6 +68,13 @@ func (s *Server) WithPeerManager(pm *PeerManager) *Server {
 	return s
 }
 
+// WithShutdownTimeout sets the time Down waits for the server process to exit before killing it,
+// and returns the updated Server instance.
+func (s *Server) WithShutdownTimeout(d time.Duration) *Server {
+	s.shutdownTimeout = d
+	return s
+}
+
 // configFilePath returns the full path of the V2Ray server's configuration file.
 func (s *Server) configFilePath() string {
 	return filepath.Join(s.homeDir, fmt.Sprintf("%s.json", s.name))
@@ -67,39 +85,13 @@ func (s *Server) pidFilePath() string {
 	return filepath.Join(s.homeDir, fmt.Sprintf("%s.pid", s.name))
 }
 
-// readPIDFromFile reads the PID from the server's PID file.
-func (s *Server) readPIDFromFile() (int32, error) {
-	name := s.pidFilePath()
-	if _, err := os.Stat(name); os.IsNotExist(err) {
-		return 0, nil
-	}
-
-	// Read PID from the PID file.
-	data, err := os.ReadFile(name)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	// Convert PID data to integer.
-	pid, err := strconv.ParseInt(string(data), 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse pid: %w", err)
-	}
-
-	return int32(pid), nil
-}
-
-// writePIDToFile writes the given PID to the server's PID file.
-func (s *Server) writePIDToFile(pid int) error {
-	// Convert PID to byte slice.
-	data := []byte(strconv.Itoa(pid))
-
-	// Write PID to file with appropriate permissions.
-	if err := os.WriteFile(s.pidFilePath(), data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// pidFile returns the PID file of the server, creating it on first use.
+func (s *Server) pidFile() *utils.PIDFile {
+	if s.pid == nil {
+		s.pid = utils.NewPIDFile(s.pidFilePath())
 	}
 
-	return nil
+	return s.pid
 }
 
 // clientConn establishes a gRPC client connection to the V2Ray server.
@@ -158,7 +150,7 @@ func (s *Server) Type() types.ServiceType {
 // IsUp checks if the V2Ray server process is running.
 func (s *Server) IsUp(ctx context.Context) (bool, error) {
 	// Read PID from file.
-	pid, err := s.readPIDFromFile()
+	pid, err := s.pidFile().Read(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to read pid from file: %w", err)
 	}
@@ -207,7 +199,17 @@ func (s *Server) PreUp(v interface{}) error {
 		return fmt.Errorf("invalid parameter type %T", v)
 	}
 
+	// Check that the API and inbound ports are free, identifying the processes using them otherwise.
+	if err := cfg.checkPorts(); err != nil {
+		return fmt.Errorf("failed to check ports: %w", err)
+	}
+
 	for _, inbound := range cfg.Inbounds {
+		// Generate or renew the TLS certificate if automatic renewal is enabled.
+		if err := inbound.EnsureTLSCertificate(); err != nil {
+			return fmt.Errorf("failed to ensure tls certificate: %w", err)
+		}
+
 		metadata := &ServerMetadata{
 			Tag: inbound.Tag(),
 		}
@@ -225,6 +227,11 @@ func (s *Server) PreUp(v interface{}) error {
 
 // Up starts the V2Ray server process.
 func (s *Server) Up(ctx context.Context) error {
+	// Lock the PID file to prevent another instance from using the same name.
+	if err := s.pidFile().Lock(); err != nil {
+		return fmt.Errorf("failed to lock pid file: %w", err)
+	}
+
 	// Constructs the command to start the V2Ray server.
 	s.cmd = exec.CommandContext(
 		ctx,
@@ -234,6 +241,7 @@ func (s *Server) Up(ctx context.Context) error {
 
 	// Starts the V2Ray server process.
 	if err := s.cmd.Start(); err != nil {
+		_ = s.pidFile().Unlock()
 		return fmt.Errorf("failed to start command: %w", err)
 	}
 
@@ -248,7 +256,7 @@ func (s *Server) PostUp() error {
 	}
 
 	// Write PID to file.
-	if err := s.writePIDToFile(s.cmd.Process.Pid); err != nil {
+	if err := s.pidFile().Write(context.Background(), s.cmd.Process.Pid); err != nil {
 		return fmt.Errorf("failed to write pid to file: %w", err)
 	}
 
@@ -264,13 +272,17 @@ func (s *Server) PreDown() error {
 	return nil
 }
 
-// Down terminates the V2Ray server process.
+// Down terminates the V2Ray server process. It sends SIGTERM and waits up to the shutdown timeout
+// for the process to exit, then kills it if it is still running.
 func (s *Server) Down(ctx context.Context) error {
 	// Read PID from file.
-	pid, err := s.readPIDFromFile()
+	pid, err := s.pidFile().Read(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to read pid from file: %w", err)
 	}
+	if pid == 0 {
+		return nil
+	}
 
 	// Retrieve process with the given PID.
 	proc, err := process.NewProcessWithContext(ctx, pid)
@@ -287,13 +299,64 @@ func (s *Server) Down(ctx context.Context) error {
 		return fmt.Errorf("failed to terminate process: %w", err)
 	}
 
+	// Wait for the process to exit within the shutdown timeout.
+	exited, err := waitForExit(ctx, proc, s.shutdownTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to wait for process: %w", err)
+	}
+	if exited {
+		return nil
+	}
+
+	// Kill the process if it is still running after the timeout.
+	if err := proc.KillWithContext(ctx); err != nil {
+		if ok, _ := proc.IsRunningWithContext(ctx); !ok {
+			return nil
+		}
+
+		return fmt.Errorf("failed to kill process: %w", err)
+	}
+
 	return nil
 }
 
+// waitForExit polls the process until it is no longer running or the timeout elapses, and reports
+// whether it has exited.
+func waitForExit(ctx context.Context, proc *process.Process, timeout time.Duration) (bool, error) {
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		// Check if the process is still running.
+		ok, err := proc.IsRunningWithContext(ctx)
+		if err != nil {
+			if errors.Is(err, process.ErrorProcessNotRunning) {
+				return true, nil
+			}
+
+			return false, err
+		}
+		if !ok {
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timer.C:
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
+
 // PostDown performs cleanup operations after the server process is terminated.
 func (s *Server) PostDown() error {
 	// Remove PID file.
-	if err := utils.RemoveFile(s.pidFilePath()); err != nil {
+	if err := s.pidFile().Remove(); err != nil {
 		return fmt.Errorf("failed to remove file: %w", err)
 	}
 
@@ -319,13 +382,19 @@ func (s *Server) AddPeer(ctx context.Context, req interface{}) (interface{}, err
 
 	// Ensure the connection is closed when done.
 	defer func() {
-		if err = conn.Close(); err != nil {
-			panic(err)
+		if cerr := conn.Close(); cerr != nil {
+			panic(cerr)
 		}
 	}()
 
-	// Extract key from the request.
-	email := r.Key()
+	// Extract the identifier of the peer from the request, which must be unique, as must its key.
+	email := r.Email()
+	if s.pm.Get(email) != nil {
+		return nil, fmt.Errorf("peer %s already exists", email)
+	}
+	if _, ok := s.pm.Email(r.Key()); ok {
+		return nil, fmt.Errorf("peer with key %s already exists", r.Key())
+	}
 
 	for _, md := range s.metadata {
 		// Prepare gRPC request to add a new user to the handler.
@@ -348,11 +417,15 @@ func (s *Server) AddPeer(ctx context.Context, req interface{}) (interface{}, err
 	}
 
 	// Update the local peer collection with the new peer information.
-	s.pm.Put(
+	ok = s.pm.Put(
 		&Peer{
 			Email: email,
+			UUID:  r.Key(),
 		},
 	)
+	if !ok {
+		return nil, fmt.Errorf("peer %s already exists", email)
+	}
 
 	// Return nil for success (no additional data to return in response).
 	return &AddPeerResponse{
@@ -371,12 +444,13 @@ func (s *Server) HasPeer(_ context.Context, req interface{}) (bool, error) {
 		return false, fmt.Errorf("invalid request: %w", err)
 	}
 
-	// Retrieve the key from the request.
-	email := r.Key()
-	peer := s.pm.Get(email)
+	// Resolve the identifier of the peer from its key.
+	if _, err := s.resolveEmail(r.Key(), r.Identifier); err != nil {
+		return false, nil
+	}
 
-	// Return true if the peer exists, otherwise false.
-	return peer != nil, nil
+	// Return true since the peer exists.
+	return true, nil
 }
 
 // RemovePeer removes a peer from the V2Ray server.
@@ -390,6 +464,12 @@ func (s *Server) RemovePeer(ctx context.Context, req interface{}) error {
 		return fmt.Errorf("invalid request: %w", err)
 	}
 
+	// Resolve the identifier the peer was added with from its key.
+	email, err := s.resolveEmail(r.Key(), r.Identifier)
+	if err != nil {
+		return err
+	}
+
 	// Establish a gRPC client connection to the handler service.
 	conn, client, err := s.handlerServiceClient()
 	if err != nil {
@@ -398,14 +478,11 @@ func (s *Server) RemovePeer(ctx context.Context, req interface{}) error {
 
 	// Ensure the connection is closed when done.
 	defer func() {
-		if err = conn.Close(); err != nil {
-			panic(err)
+		if cerr := conn.Close(); cerr != nil {
+			panic(cerr)
 		}
 	}()
 
-	// Extract key from the request.
-	email := r.Key()
-
 	for _, md := range s.metadata {
 		// Prepare gRPC request to remove a user from the handler.
 		in := &proxymancommand.AlterInboundRequest{
@@ -427,19 +504,51 @@ func (s *Server) RemovePeer(ctx context.Context, req interface{}) error {
 	}
 
 	// Remove the peer information from the local collection.
-	s.pm.Delete(email)
+	if !s.pm.Delete(email) {
+		return fmt.Errorf("peer %s does not exist", email)
+	}
 
 	// Return nil for success.
 	return nil
 }
 
+// resolveEmail returns the email the peer with the given key was added with. If an identifier is
+// given, it must match that email.
+func (s *Server) resolveEmail(key, identifier string) (string, error) {
+	email, ok := s.pm.Email(key)
+	if !ok {
+		return "", fmt.Errorf("peer with key %s does not exist", key)
+	}
+	if identifier != "" && identifier != email {
+		return "", fmt.Errorf("peer with key %s does not have identifier %s", key, identifier)
+	}
+
+	return email, nil
+}
+
 // PeerCount returns the number of peers connected to the V2Ray server.
 func (s *Server) PeerCount() int {
 	return s.pm.Len()
 }
 
 // PeerStatistics retrieves statistics for each peer connected to the V2Ray server.
-func (s *Server) PeerStatistics(ctx context.Context) (items []*types.PeerStatistic, err error) {
+// The key of each statistic is the email of the peer, which is the identifier given in AddPeerRequest,
+// or the base64-encoded UUID if none was given.
+func (s *Server) PeerStatistics(ctx context.Context) ([]*types.PeerStatistic, error) {
+	return s.peerStatistics(ctx, false)
+}
+
+// PeerStatisticsReset retrieves statistics for each peer connected to the V2Ray server like PeerStatistics,
+// resetting the traffic counters as they are read. The returned statistics hold the traffic since the
+// previous reset, so that consumption is not counted twice between polling intervals.
+func (s *Server) PeerStatisticsReset(ctx context.Context) ([]*types.PeerStatistic, error) {
+	return s.peerStatistics(ctx, true)
+}
+
+// peerStatistics retrieves statistics for each peer, resetting the traffic counters if reset is true.
+// The counters of all peers are fetched with a single QueryStats call, falling back to GetStats calls for
+// each peer if the stats service does not implement it.
+func (s *Server) peerStatistics(ctx context.Context, reset bool) ([]*types.PeerStatistic, error) {
 	// Establish a gRPC client connection to the stats service.
 	conn, client, err := s.statsServiceClient()
 	if err != nil {
@@ -448,62 +557,49 @@ func (s *Server) PeerStatistics(ctx context.Context) (items []*types.PeerStatist
 
 	// Ensure the connection is closed when done.
 	defer func() {
-		if err = conn.Close(); err != nil {
-			panic(err)
+		if cerr := conn.Close(); cerr != nil {
+			panic(cerr)
 		}
 	}()
 
-	// Define a function to process each peer in the local collection.
-	fn := func(key string, _ *Peer) (bool, error) {
-		// Prepare gRPC request to get uplink traffic stats.
-		in := &statscommand.GetStatsRequest{
-			Reset_: false,
-			Name:   fmt.Sprintf("user>>>%s>>>traffic>>>uplink", key),
-		}
+	return s.collectPeerStatistics(ctx, client, reset)
+}
 
-		// Send the request to get uplink traffic stats.
-		res, err := client.GetStats(ctx, in)
-		if err != nil {
-			// If the stat is not found, continue to the next peer.
-			if !strings.Contains(err.Error(), "not found") {
-				return false, fmt.Errorf("failed to get stats: %w", err)
-			}
-		}
+// collectPeerStatistics retrieves statistics for each peer from the stats service client, resetting the
+// traffic counters if reset is true. Errors of the QueryStats call and of the GetStats fallback are returned.
+func (s *Server) collectPeerStatistics(ctx context.Context, client statscommand.StatsServiceClient, reset bool) (items []*types.PeerStatistic, err error) {
+	// V2Ray reports only traffic counters, so the connection state and endpoint are left unset.
+	collectedAt := time.Now()
 
-		// Extract uplink traffic stats or use an empty stat if not found.
-		upLink := res.GetStat()
-		if upLink == nil {
-			upLink = &statscommand.Stat{}
+	// Query the traffic counters of all peers at once, leaving the map nil if unsupported.
+	traffic, err := queryUserTraffic(ctx, client, reset)
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			return nil, fmt.Errorf("failed to query stats: %w", err)
 		}
 
-		// Prepare gRPC request to get downlink traffic stats.
-		in = &statscommand.GetStatsRequest{
-			Reset_: false,
-			Name:   fmt.Sprintf("user>>>%s>>>traffic>>>downlink", key),
-		}
+		traffic = nil
+	}
 
-		// Send the request to get downlink traffic stats.
-		res, err = client.GetStats(ctx, in)
-		if err != nil {
-			// If the stat is not found, continue to the next peer.
-			if !strings.Contains(err.Error(), "not found") {
-				return false, fmt.Errorf("failed to get stats: %w", err)
+	// Define a function to process each peer in the local collection.
+	fn := func(key string, _ *Peer) (bool, error) {
+		t, ok := traffic[key]
+		if !ok && traffic == nil {
+			// Get the traffic counters of the peer one by one.
+			var err error
+			if t, err = getUserTraffic(ctx, client, key, reset); err != nil {
+				return false, err
 			}
 		}
 
-		// Extract downlink traffic stats or use an empty stat if not found.
-		downLink := res.GetStat()
-		if downLink == nil {
-			downLink = &statscommand.Stat{}
-		}
-
 		// Append peer statistics to the result collection.
 		items = append(
 			items,
 			&types.PeerStatistic{
 				Key:           key,
-				DownloadBytes: downLink.GetValue(),
-				UploadBytes:   upLink.GetValue(),
+				CollectedAt:   collectedAt,
+				DownloadBytes: t.downlink,
+				UploadBytes:   t.uplink,
 			},
 		)
 
@@ -518,3 +614,73 @@ func (s *Server) PeerStatistics(ctx context.Context) (items []*types.PeerStatist
 	// Return the constructed collection of peer statistics.
 	return items, nil
 }
+
+// userTraffic holds the traffic counters of a V2Ray user.
+type userTraffic struct {
+	downlink int64
+	uplink   int64
+}
+
+// queryUserTraffic fetches the traffic counters of all users with a single QueryStats call and returns
+// them keyed by email, resetting the counters if reset is true.
+func queryUserTraffic(ctx context.Context, client statscommand.StatsServiceClient, reset bool) (map[string]userTraffic, error) {
+	// Query all counters of users, which are named "user>>>{email}>>>traffic>>>{uplink|downlink}".
+	res, err := client.QueryStats(ctx, &statscommand.QueryStatsRequest{
+		Patterns: []string{"user>>>"},
+		Reset_:   reset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]userTraffic)
+	for _, stat := range res.GetStat() {
+		parts := strings.Split(stat.GetName(), ">>>")
+		if len(parts) != 4 || parts[0] != "user" || parts[2] != "traffic" {
+			continue
+		}
+
+		t := m[parts[1]]
+		switch parts[3] {
+		case "uplink":
+			t.uplink += stat.GetValue()
+		case "downlink":
+			t.downlink += stat.GetValue()
+		}
+
+		m[parts[1]] = t
+	}
+
+	return m, nil
+}
+
+// getUserTraffic fetches the traffic counters of the user with the given email using a GetStats call for
+// each direction, resetting the counters if reset is true. Missing counters are reported as zero.
+func getUserTraffic(ctx context.Context, client statscommand.StatsServiceClient, email string, reset bool) (t userTraffic, err error) {
+	// Define a function to get a single counter of the user.
+	get := func(direction string) (int64, error) {
+		in := &statscommand.GetStatsRequest{
+			Reset_: reset,
+			Name:   fmt.Sprintf("user>>>%s>>>traffic>>>%s", email, direction),
+		}
+
+		res, err := client.GetStats(ctx, in)
+		if err != nil {
+			// If the stat is not found, report it as zero.
+			if !strings.Contains(err.Error(), "not found") {
+				return 0, fmt.Errorf("failed to get stats: %w", err)
+			}
+		}
+
+		return res.GetStat().GetValue(), nil
+	}
+
+	if t.uplink, err = get("uplink"); err != nil {
+		return t, err
+	}
+	if t.downlink, err = get("downlink"); err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
@@ -6,18 +6,24 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/qubetics/qubetics-go-sdk/libs/tls"
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
 )
 
+// apiServerPort is the port of the API inbound defined in server.json.tmpl.
+const apiServerPort uint16 = 2323
+
 // InboundServerConfig represents the V2Ray inbound server configuration options.
 type InboundServerConfig struct {
-	Port        string `mapstructure:"port"`          // Port defines the inbound port range.
-	Proxy       string `mapstructure:"proxy"`         // Proxy defines the protocol used (e.g., vmess).
-	Security    string `mapstructure:"security"`      // Security specifies the encryption method.
-	TLSCertPath string `mapstructure:"tls_cert_path"` // TLSCertPath specifies the path to the TLS certificate.
-	TLSKeyPath  string `mapstructure:"tls_key_path"`  // TLSKeyPath specifies the path to the TLS private key.
-	Transport   string `mapstructure:"transport"`     // Transport specifies the transport protocol.
+	Port         string   `mapstructure:"port"`           // Port defines the inbound port range.
+	Proxy        string   `mapstructure:"proxy"`          // Proxy defines the protocol used (e.g., vmess).
+	Security     string   `mapstructure:"security"`       // Security specifies the encryption method.
+	TLSAddrs     []string `mapstructure:"tls_addrs"`      // TLSAddrs lists the public IP addresses and DNS names the auto-renewed certificate is issued for.
+	TLSAutoRenew bool     `mapstructure:"tls_auto_renew"` // TLSAutoRenew generates a self-signed certificate if missing and renews it before expiry.
+	TLSCertPath  string   `mapstructure:"tls_cert_path"`  // TLSCertPath specifies the path to the TLS certificate.
+	TLSKeyPath   string   `mapstructure:"tls_key_path"`   // TLSKeyPath specifies the path to the TLS private key.
+	Transport    string   `mapstructure:"transport"`      // Transport specifies the transport protocol.
 }
 
 // GetPort parses and returns the port configuration.
@@ -40,6 +46,21 @@ func (c *InboundServerConfig) OutPort() string {
 	return c.GetPort().OutPort()
 }
 
+// EnsureTLSCertificate generates or renews the self-signed TLS certificate of the inbound for its
+// TLSAddrs if it uses TLS security and TLSAutoRenew is enabled.
+func (c *InboundServerConfig) EnsureTLSCertificate() error {
+	if !c.TLSAutoRenew || NewTransportSecurityFromString(c.Security) != TransportSecurityTLS {
+		return nil
+	}
+
+	cert := tls.NewCertificate().
+		WithAddrs(c.TLSAddrs).
+		WithCertPath(c.TLSCertPath).
+		WithKeyPath(c.TLSKeyPath)
+
+	return cert.EnsureValid()
+}
+
 // Tag creates a Tag instance based on the InboundServerConfig configuration.
 func (c *InboundServerConfig) Tag() *Tag {
 	proxy := NewProxyProtocolFromString(c.Proxy)
@@ -85,6 +106,16 @@ func (c *InboundServerConfig) Validate() error {
 		if c.TLSKeyPath == "" {
 			return errors.New("tls_key_path cannot be empty")
 		}
+
+		// Ensure the auto-renewed certificate is issued for the public addresses of the server.
+		if c.TLSAutoRenew {
+			if len(c.TLSAddrs) == 0 {
+				return errors.New("tls_addrs cannot be empty when tls_auto_renew is enabled")
+			}
+			if err := tls.NewCertificate().WithAddrs(c.TLSAddrs).Validate(); err != nil {
+				return fmt.Errorf("invalid tls_addrs: %w", err)
+			}
+		}
 	}
 
 	// Validate the Transport protocol.
@@ -124,20 +155,22 @@ func (c *ServerConfig) Validate() error {
 			panic(err)
 		}
 
-		// Check inbound ports for duplicates.
-		for p := port.InFrom; p <= port.InTo; p++ {
-			if inPortSet[p] {
-				return fmt.Errorf("duplicate in port %d", p)
+		for _, r := range port.Ranges() {
+			// Check inbound ports for duplicates.
+			for p := uint32(r.InFrom); p <= uint32(r.InTo); p++ {
+				if inPortSet[uint16(p)] {
+					return fmt.Errorf("duplicate in port %d", p)
+				}
+				inPortSet[uint16(p)] = true
 			}
-			inPortSet[p] = true
-		}
 
-		// Check outbound ports for duplicates.
-		for p := port.OutFrom; p <= port.OutTo; p++ {
-			if outPortSet[p] {
-				return fmt.Errorf("duplicate out port %d", p)
+			// Check outbound ports for duplicates.
+			for p := uint32(r.OutFrom); p <= uint32(r.OutTo); p++ {
+				if outPortSet[uint16(p)] {
+					return fmt.Errorf("duplicate out port %d", p)
+				}
+				outPortSet[uint16(p)] = true
 			}
-			outPortSet[p] = true
 		}
 
 		// Check tags for duplicates.
@@ -151,6 +184,36 @@ func (c *ServerConfig) Validate() error {
 	return nil
 }
 
+// listenPorts returns the ports bound by the server for each network, which are the API port and the
+// configured inbound ports. Only the bounds of a port range are included, rather than every port in it.
+func (c *ServerConfig) listenPorts() map[string][]uint16 {
+	ports := map[string][]uint16{"tcp": {apiServerPort}}
+	for _, inbound := range c.Inbounds {
+		network := NewTransportProtocolFromString(inbound.Transport).Network()
+		if network == "" {
+			continue
+		}
+
+		for _, r := range inbound.GetPort().Ranges() {
+			ports[network] = append(ports[network], r.InFrom)
+			if r.InTo != r.InFrom {
+				ports[network] = append(ports[network], r.InTo)
+			}
+		}
+	}
+
+	return ports
+}
+
+// checkPorts checks that the ports bound by the server are free, identifying the processes using them otherwise.
+func (c *ServerConfig) checkPorts() error {
+	ports := c.listenPorts()
+	return errors.Join(
+		utils.CheckProtoPorts("tcp", ports["tcp"]...),
+		utils.CheckProtoPorts("udp", ports["udp"]...),
+	)
+}
+
 // WriteToFile writes the server configuration to a file.
 func (c *ServerConfig) WriteToFile(name string) error {
 	// Read the server configuration template file.
@@ -160,7 +223,7 @@ func (c *ServerConfig) WriteToFile(name string) error {
 	}
 
 	// Execute the template and write it to the specified file.
-	if err := utils.ExecTemplateToFile(string(text), c, name); err != nil {
+	if err := utils.ExecTemplateToFile(string(text), c, name, 0600); err != nil {
 		return fmt.Errorf("failed to execute template to file: %w", err)
 	}
 
@@ -172,23 +235,31 @@ func (c *ServerConfig) SetForFlags(_ *pflag.FlagSet) {}
 
 // DefaultServerConfig creates a default ServerConfig with predefined values.
 func DefaultServerConfig() *ServerConfig {
+	// Pick distinct free ports for the inbounds, avoiding the API inbound port.
+	grpcPort := utils.MustRandomFreePort("tcp", apiServerPort)
+	tcpPort := utils.MustRandomFreePort("tcp", apiServerPort, grpcPort)
+
 	return &ServerConfig{
 		Inbounds: []*InboundServerConfig{
 			{
-				Port:        fmt.Sprintf("%d", utils.RandomPort()),
-				Proxy:       "vmess",
-				Security:    "none",
-				TLSCertPath: "",
-				TLSKeyPath:  "",
-				Transport:   "grpc",
+				Port:         fmt.Sprintf("%d", grpcPort),
+				Proxy:        "vmess",
+				Security:     "none",
+				TLSAddrs:     nil,
+				TLSAutoRenew: false,
+				TLSCertPath:  "",
+				TLSKeyPath:   "",
+				Transport:    "grpc",
 			},
 			{
-				Port:        fmt.Sprintf("%d", utils.RandomPort()),
-				Proxy:       "vmess",
-				Security:    "none",
-				TLSCertPath: "",
-				TLSKeyPath:  "",
-				Transport:   "tcp",
+				Port:         fmt.Sprintf("%d", tcpPort),
+				Proxy:        "vmess",
+				Security:     "none",
+				TLSAddrs:     nil,
+				TLSAutoRenew: false,
+				TLSCertPath:  "",
+				TLSKeyPath:   "",
+				Transport:    "tcp",
 			},
 		},
 	}
@@ -0,0 +1,169 @@
+package v2ray
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	statscommand "github.com/v2fly/v2ray-core/v5/app/stats/command"
+	"github.com/v2fly/v2ray-core/v5/common/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStatsClient is a StatsServiceClient answering QueryStats and GetStats with functions.
+type fakeStatsClient struct {
+	statscommand.StatsServiceClient
+
+	query func(in *statscommand.QueryStatsRequest) (*statscommand.QueryStatsResponse, error)
+	get   func(in *statscommand.GetStatsRequest) (*statscommand.GetStatsResponse, error)
+}
+
+// QueryStats implements the StatsServiceClient interface.
+func (c *fakeStatsClient) QueryStats(_ context.Context, in *statscommand.QueryStatsRequest, _ ...grpc.CallOption) (*statscommand.QueryStatsResponse, error) {
+	return c.query(in)
+}
+
+// GetStats implements the StatsServiceClient interface.
+func (c *fakeStatsClient) GetStats(_ context.Context, in *statscommand.GetStatsRequest, _ ...grpc.CallOption) (*statscommand.GetStatsResponse, error) {
+	return c.get(in)
+}
+
+// unimplementedQuery answers QueryStats like a stats service that does not implement it.
+func unimplementedQuery(*statscommand.QueryStatsRequest) (*statscommand.QueryStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method QueryStats")
+}
+
+func TestCollectPeerStatistics(t *testing.T) {
+	errUnavailable := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		client  *fakeStatsClient
+		want    map[string][2]int64
+		wantErr error
+	}{
+		{
+			name: "query stats",
+			client: &fakeStatsClient{
+				query: func(*statscommand.QueryStatsRequest) (*statscommand.QueryStatsResponse, error) {
+					return &statscommand.QueryStatsResponse{
+						Stat: []*statscommand.Stat{
+							{Name: "user>>>alice>>>traffic>>>uplink", Value: 10},
+							{Name: "user>>>alice>>>traffic>>>downlink", Value: 20},
+							{Name: "inbound>>>api>>>traffic>>>uplink", Value: 99},
+						},
+					}, nil
+				},
+			},
+			want: map[string][2]int64{"alice": {20, 10}, "bob": {0, 0}},
+		},
+		{
+			name: "query stats failure",
+			client: &fakeStatsClient{
+				query: func(*statscommand.QueryStatsRequest) (*statscommand.QueryStatsResponse, error) {
+					return nil, errUnavailable
+				},
+			},
+			wantErr: errUnavailable,
+		},
+		{
+			name: "get stats fallback",
+			client: &fakeStatsClient{
+				query: unimplementedQuery,
+				get: func(in *statscommand.GetStatsRequest) (*statscommand.GetStatsResponse, error) {
+					if in.Name == "user>>>alice>>>traffic>>>downlink" {
+						return &statscommand.GetStatsResponse{Stat: &statscommand.Stat{Name: in.Name, Value: 5}}, nil
+					}
+
+					return nil, status.Error(codes.Unknown, in.Name+" not found")
+				},
+			},
+			want: map[string][2]int64{"alice": {5, 0}, "bob": {0, 0}},
+		},
+		{
+			name: "get stats fallback failure",
+			client: &fakeStatsClient{
+				query: unimplementedQuery,
+				get: func(*statscommand.GetStatsRequest) (*statscommand.GetStatsResponse, error) {
+					return nil, errUnavailable
+				},
+			},
+			wantErr: errUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := NewPeerManager()
+			pm.Put(&Peer{Email: "alice"})
+			pm.Put(&Peer{Email: "bob"})
+
+			s := NewServer().WithPeerManager(pm)
+
+			items, err := s.collectPeerStatistics(context.Background(), tt.client, false)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("collectPeerStatistics() error = %v, want %v", err, tt.wantErr)
+				}
+				if items != nil {
+					t.Errorf("collectPeerStatistics() = %v, want no statistics", items)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("collectPeerStatistics() error = %v", err)
+			}
+
+			if len(items) != len(tt.want) {
+				t.Fatalf("collectPeerStatistics() = %d statistics, want %d", len(items), len(tt.want))
+			}
+			for _, item := range items {
+				want, ok := tt.want[item.Key]
+				if !ok {
+					t.Errorf("unexpected statistic for %s", item.Key)
+					continue
+				}
+				if item.DownloadBytes != want[0] || item.UploadBytes != want[1] {
+					t.Errorf("statistic of %s = %d/%d, want %d/%d", item.Key, item.DownloadBytes, item.UploadBytes, want[0], want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestHasPeer(t *testing.T) {
+	id := uuid.New()
+	key := (&HasPeerRequest{UUID: id}).Key()
+
+	pm := NewPeerManager()
+	pm.Put(&Peer{Email: "session-42", UUID: key})
+
+	s := NewServer().WithPeerManager(pm)
+
+	tests := []struct {
+		name string
+		req  *HasPeerRequest
+		want bool
+	}{
+		{name: "unknown key", req: &HasPeerRequest{UUID: uuid.New()}, want: false},
+		{name: "key", req: &HasPeerRequest{UUID: id}, want: true},
+		{name: "key and identifier", req: &HasPeerRequest{Identifier: "session-42", UUID: id}, want: true},
+		{name: "key and other identifier", req: &HasPeerRequest{Identifier: "session-43", UUID: id}, want: false},
+		{name: "identifier as key", req: &HasPeerRequest{Identifier: key, UUID: id}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.HasPeer(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("HasPeer() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasPeer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
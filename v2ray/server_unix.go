@@ -6,3 +6,6 @@ package v2ray
 func (s *Server) execFile(name string) string {
 	return name
 }
+
+// serverBinaries are the executables run by the V2Ray server.
+var serverBinaries = []string{v2ray}
@@ -8,3 +8,6 @@ import (
 func (s *Server) execFile(name string) string {
 	return ".\\" + filepath.Join("V2Ray", name+".exe")
 }
+
+// serverBinaries are the executables run by the V2Ray server.
+var serverBinaries = []string{v2ray}
@@ -45,6 +45,19 @@ func (t TransportProtocol) IsValid() bool {
 	return t.String() != ""
 }
 
+// Network returns the network (tcp or udp) the transport protocol listens on, or an empty string for
+// transport protocols that do not listen on a port.
+func (t TransportProtocol) Network() string {
+	switch t {
+	case TransportProtocolMKCP, TransportProtocolQUIC:
+		return "udp"
+	case TransportProtocolGUN, TransportProtocolGRPC, TransportProtocolHTTP, TransportProtocolTCP, TransportProtocolWebSocket:
+		return "tcp"
+	default:
+		return ""
+	}
+}
+
 // NewTransportProtocolFromString converts a string to a TransportProtocol type.
 func NewTransportProtocolFromString(v string) TransportProtocol {
 	switch v {
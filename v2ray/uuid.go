@@ -1,9 +1,16 @@
 package v2ray
 
 import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+
 	"github.com/v2fly/v2ray-core/v5/common/uuid"
 )
 
+// uuidNamespace is the namespace of the UUIDs derived from seeds by NewUUIDFromSeed.
+var uuidNamespace = uuid.UUID{137, 8, 232, 152, 92, 188, 74, 122, 134, 202, 8, 242, 212, 101, 179, 241}
+
 // NewUUID generates and returns a new UUID.
 func NewUUID() uuid.UUID {
 	return uuid.New()
@@ -14,3 +21,56 @@ func NewStringUUID() string {
 	i := NewUUID()
 	return i.String()
 }
+
+// NewUUIDFromSeed deterministically derives a version 5 UUID from the seed, such as the public key or
+// account address of a peer, so that the same client ID can be regenerated across restarts without
+// storing it. NewUUID remains the default for random client IDs.
+func NewUUIDFromSeed(seed string) (uuid.UUID, error) {
+	if seed == "" {
+		return uuid.UUID{}, errors.New("seed cannot be empty")
+	}
+
+	// Hash the namespace and the seed with SHA-1, and set the version and variant bits as defined by RFC 4122.
+	h := sha1.New()
+	h.Write(uuidNamespace[:])
+	h.Write([]byte(seed))
+	sum := h.Sum(nil)
+
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	id, err := uuid.ParseBytes(sum[:16])
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("failed to parse uuid: %w", err)
+	}
+
+	// Ensure the derived UUID is accepted by V2Ray in its string form.
+	if err := ValidateUUID(id.String()); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return id, nil
+}
+
+// NewStringUUIDFromSeed derives a UUID from the seed like NewUUIDFromSeed and returns it as a string.
+func NewStringUUIDFromSeed(seed string) (string, error) {
+	id, err := NewUUIDFromSeed(seed)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// ValidateUUID checks that the string is a UUID accepted by V2Ray as a client ID.
+func ValidateUUID(s string) error {
+	id, err := uuid.ParseString(s)
+	if err != nil {
+		return fmt.Errorf("invalid uuid %q: %w", s, err)
+	}
+	if id == (uuid.UUID{}) {
+		return fmt.Errorf("invalid uuid %q: must not be zero", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package v2ray
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/qubetics/qubetics-go-sdk/utils"
+)
+
+// CheckBinaries checks that the executables run by the server are available.
+func (s *Server) CheckBinaries() error {
+	var errs []error
+	for _, name := range serverBinaries {
+		if _, err := exec.LookPath(s.execFile(name)); err != nil {
+			errs = append(errs, fmt.Errorf("binary %s is not available: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateConfig checks the configuration without starting the server, reporting all problems at once.
+// It validates the configuration, renders its template and checks that the output is valid JSON,
+// checks that the executables are available, and checks that the inbound and API ports are free.
+func (s *Server) ValidateConfig(cfg *ServerConfig) error {
+	var errs []error
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid config: %w", err))
+	} else {
+		// Render the template, which relies on a valid configuration.
+		text, err := fs.ReadFile("server.json.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+
+		buf, err := utils.ExecTemplate(string(text), cfg, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to render config: %w", err))
+		} else if !json.Valid(buf) {
+			errs = append(errs, errors.New("rendered config is not valid json"))
+		}
+
+		// Check that the inbound and API ports can be bound.
+		if err := cfg.checkPorts(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := s.CheckBinaries(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
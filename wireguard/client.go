@@ -18,8 +18,9 @@ var _ types.ClientService = (*Client)(nil)
 
 // Client represents a WireGuard client with associated home directory and name.
 type Client struct {
-	homeDir string // Home directory for client files.
-	name    string // Name of the interface.
+	homeDir      string // Home directory for client files.
+	name         string // Name of the interface.
+	secureRemove bool   // Overwrite the configuration file before removing it.
 }
 
 // NewClient creates a new Client instance.
@@ -39,6 +40,22 @@ func (c *Client) WithName(name string) *Client {
 	return c
 }
 
+// WithSecureRemove sets whether the configuration file, which contains the private key, is overwritten
+// before it is removed in PostDown, and returns the updated Client instance.
+func (c *Client) WithSecureRemove(secureRemove bool) *Client {
+	c.secureRemove = secureRemove
+	return c
+}
+
+// removeFile removes the file at the specified path, overwriting it first if secure removal is enabled.
+func (c *Client) removeFile(path string) error {
+	if c.secureRemove {
+		return utils.RemoveFileSecure(path)
+	}
+
+	return utils.RemoveFile(path)
+}
+
 // configFilePath returns the file path of the client's configuration file.
 func (c *Client) configFilePath() string {
 	return filepath.Join(c.homeDir, fmt.Sprintf("%s.conf", c.name))
@@ -110,7 +127,7 @@ func (c *Client) PreDown() error {
 // PostDown performs cleanup operations after the client process is terminated.
 func (c *Client) PostDown() error {
 	// Removes configuration file.
-	if err := utils.RemoveFile(c.configFilePath()); err != nil {
+	if err := c.removeFile(c.configFilePath()); err != nil {
 		return fmt.Errorf("failed to remove config: %w", err)
 	}
 
@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
-	"os"
 
 	"github.com/spf13/pflag"
 
+	"github.com/qubetics/qubetics-go-sdk/config"
 	"github.com/qubetics/qubetics-go-sdk/utils"
 )
 
@@ -84,6 +84,24 @@ type ClientConfig struct {
 	PrivateKey   string            `mapstructure:"private_key"`   // PrivateKey holds the WireGuard private key for this client.
 }
 
+// Redacted returns a copy of the configuration with the private key masked.
+func (c *ClientConfig) Redacted() *ClientConfig {
+	v := *c
+	v.PrivateKey = config.RedactString(v.PrivateKey)
+
+	return &v
+}
+
+// RedactedSection returns the redacted configuration as a config.Section.
+func (c *ClientConfig) RedactedSection() config.Section {
+	return c.Redacted()
+}
+
+// String returns the redacted configuration encoded as JSON, so the private key never appears in logs.
+func (c *ClientConfig) String() string {
+	return string(utils.MustMarshalJSON(c.Redacted()))
+}
+
 // GetAddrs returns the list of addresses (Addrs) as netip.Prefixes.
 func (c *ClientConfig) GetAddrs() []netip.Prefix {
 	var addrs []netip.Prefix
@@ -194,16 +212,11 @@ func (c *ClientConfig) WriteToFile(name string) error {
 		return fmt.Errorf("failed to read template: %w", err)
 	}
 
-	// Execute the template and write it to the specified file.
-	if err := utils.ExecTemplateToFile(string(text), c, name); err != nil {
+	// Execute the template and write it to the specified file, readable and writable by the owner only.
+	if err := utils.ExecTemplateToFile(string(text), c, name, 0600); err != nil {
 		return fmt.Errorf("failed to execute template to file: %w", err)
 	}
 
-	// Change file permissions to read and write for the owner only.
-	if err := os.Chmod(name, 0600); err != nil {
-		return fmt.Errorf("failed to change file permissions: %w", err)
-	}
-
 	return nil
 }
 
@@ -232,7 +245,7 @@ func DefaultClientConfig() *ClientConfig {
 		MTU:          1420,
 		Name:         "wg0",
 		Peer:         DefaultPeerClientConfig(),
-		Port:         utils.RandomPort(),
+		Port:         utils.MustRandomFreePort("udp"),
 		PrivateKey:   privateKey.String(),
 	}
 }
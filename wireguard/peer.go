@@ -4,15 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/qubetics/qubetics-go-sdk/types"
 )
 
 // Peer represents a network peer with identity and IP addresses.
 type Peer struct {
-	ID    string // ID of the peer
-	Addrs []netip.Prefix
+	ID        string // ID of the peer
+	Addrs     []netip.Prefix
+	ExpiresAt time.Time // Expiry of the peer's address reservation, zero if it does not expire
 }
 
 // Key returns the identity of the peer as the key.
@@ -22,9 +25,10 @@ func (p *Peer) Key() string {
 
 // PeerManager manages a collection of Peers and their associated IP addresses.
 type PeerManager struct {
-	m     map[string]*Peer
-	pools []*types.IPPool
-	rwm   *sync.RWMutex
+	deterministic bool // Whether addresses are derived from the peer identity.
+	m             map[string]*Peer
+	pools         []*types.IPPool
+	rwm           *sync.RWMutex
 }
 
 // NewPeerManager creates a new instance of PeerManager.
@@ -36,6 +40,21 @@ func NewPeerManager(pools ...*types.IPPool) *PeerManager {
 	}
 }
 
+// WithDeterministicAllocation sets whether addresses are derived from the hash of the peer's public key
+// instead of being assigned sequentially, and returns the updated PeerManager.
+//
+// Deterministic allocation gives a peer the same addresses across restarts without persisting the allocations,
+// which eases crash recovery. However, peers whose hashes collide are assigned the next free addresses, which
+// depend on the order in which peers are added, and addresses are scattered across the pool instead of being
+// packed from its start. Sequential allocation is denser and predictable in order, but only stable if persisted.
+func (m *PeerManager) WithDeterministicAllocation(deterministic bool) *PeerManager {
+	m.rwm.Lock()
+	defer m.rwm.Unlock()
+
+	m.deterministic = deterministic
+	return m
+}
+
 // Get retrieves a Peer from the PeerManager by its identity.
 func (m *PeerManager) Get(v string) *Peer {
 	m.rwm.RLock()
@@ -50,6 +69,70 @@ func (m *PeerManager) Put(id string) (addrs []netip.Prefix, err error) {
 	m.rwm.Lock()
 	defer m.rwm.Unlock()
 
+	return m.put(id, time.Time{})
+}
+
+// ReserveFor reserves addresses for the peer with the given key from each pool until the duration elapses,
+// after which Server.RemoveExpiredPeers, run periodically by workers.PeerExpiry, removes the peer and releases
+// them. This ties the address lifetime to the session or lease of the peer, so addresses do not leak when a peer
+// disappears without being removed. If the peer already exists, its reservation is extended to the new expiry and
+// its current addresses are returned; an error is returned if it was added without a reservation, since it would
+// otherwise become expirable. Reservations are kept in memory only; callers persisting peers can store
+// Peer.ExpiresAt alongside them.
+func (m *PeerManager) ReserveFor(key string, d time.Duration) ([]netip.Addr, error) {
+	if d <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+
+	m.rwm.Lock()
+	defer m.rwm.Unlock()
+
+	expiresAt := time.Now().Add(d)
+
+	// Extend the reservation of an existing peer, replacing it rather than modifying the Peer handed out by Get.
+	if peer, ok := m.m[key]; ok {
+		if peer.ExpiresAt.IsZero() {
+			return nil, fmt.Errorf("peer %s was added without a reservation and does not expire", key)
+		}
+
+		m.m[key] = &Peer{
+			ID:        peer.ID,
+			Addrs:     peer.Addrs,
+			ExpiresAt: expiresAt,
+		}
+
+		return prefixAddrs(peer.Addrs), nil
+	}
+
+	prefixes, err := m.put(key, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return prefixAddrs(prefixes), nil
+}
+
+// putFor adds a new Peer with the given identity whose reservation expires after the duration.
+func (m *PeerManager) putFor(id string, d time.Duration) ([]netip.Prefix, error) {
+	m.rwm.Lock()
+	defer m.rwm.Unlock()
+
+	return m.put(id, time.Now().Add(d))
+}
+
+// prefixAddrs returns the addresses of the prefixes.
+func prefixAddrs(prefixes []netip.Prefix) []netip.Addr {
+	addrs := make([]netip.Addr, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		addrs = append(addrs, prefix.Addr())
+	}
+
+	return addrs
+}
+
+// put adds a new Peer with the given identity and expiry, assigning an address from each pool.
+// The caller must hold the write lock.
+func (m *PeerManager) put(id string, expiresAt time.Time) ([]netip.Prefix, error) {
 	if id == "" {
 		return nil, errors.New("peer id is empty")
 	}
@@ -59,29 +142,38 @@ func (m *PeerManager) Put(id string) (addrs []netip.Prefix, err error) {
 		return nil, fmt.Errorf("peer %s already exists", id)
 	}
 
+	// Release the addresses reserved so far if any pool fails.
+	var reserved []netip.Addr
 	defer func() {
-		if len(addrs) != len(m.pools) {
-			for i := 0; i < len(addrs); i++ {
-				addr := addrs[i].Addr()
-				if err := m.pools[i].Put(addr); err != nil {
-					panic(fmt.Errorf("failed to put addr %s to pool: %w", addr, err))
-				}
+		if len(reserved) == len(m.pools) {
+			return
+		}
+
+		for i, addr := range reserved {
+			if err := m.pools[i].Release(addr); err != nil {
+				panic(fmt.Errorf("failed to release addr %s to pool: %w", addr, err))
 			}
 		}
 	}()
 
+	addrs := make([]netip.Prefix, 0, len(m.pools))
 	for _, pool := range m.pools {
-		addr, err := pool.Get()
+		var (
+			addr netip.Addr
+			err  error
+		)
+		if m.deterministic {
+			addr, err = pool.ReserveHashed([]byte(id))
+		} else {
+			addr, err = pool.Reserve()
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to get addr from pool: %w", err)
+			return nil, fmt.Errorf("failed to reserve addr from pool: %w", err)
 		}
 
-		b := 32
-		if addr.Is6() {
-			b = 128
-		}
+		reserved = append(reserved, addr)
 
-		prefixAddr, err := addr.Prefix(b)
+		prefixAddr, err := addr.Prefix(pool.PeerBits())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get prefix addr: %w", err)
 		}
@@ -91,8 +183,9 @@ func (m *PeerManager) Put(id string) (addrs []netip.Prefix, err error) {
 
 	// Create and store the new Peer
 	m.m[id] = &Peer{
-		ID:    id,
-		Addrs: addrs,
+		ID:        id,
+		Addrs:     addrs,
+		ExpiresAt: expiresAt,
 	}
 
 	return addrs, nil
@@ -103,6 +196,33 @@ func (m *PeerManager) Delete(v string) {
 	m.rwm.Lock()
 	defer m.rwm.Unlock()
 
+	m.delete(v)
+}
+
+// Expired returns the identities of the peers whose reservation has expired, in ascending order.
+func (m *PeerManager) Expired() []string {
+	m.rwm.RLock()
+	defer m.rwm.RUnlock()
+
+	return m.expired(time.Now())
+}
+
+// expired returns the identities of the peers whose reservation expired by the given time, in ascending order.
+// The caller must hold the lock.
+func (m *PeerManager) expired(now time.Time) []string {
+	var keys []string
+	for key, peer := range m.m {
+		if !peer.ExpiresAt.IsZero() && !now.Before(peer.ExpiresAt) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// delete removes a Peer and releases its addresses. The caller must hold the write lock.
+func (m *PeerManager) delete(v string) {
 	// Retrieve the Peer and its IP addresses
 	item, ok := m.m[v]
 	if !ok {
@@ -111,8 +231,8 @@ func (m *PeerManager) Delete(v string) {
 
 	for i := 0; i < len(item.Addrs); i++ {
 		addr := item.Addrs[i].Addr()
-		if err := m.pools[i].Put(addr); err != nil {
-			panic(fmt.Errorf("failed to put addr %s to pool: %w", addr, err))
+		if err := m.pools[i].Release(addr); err != nil {
+			panic(fmt.Errorf("failed to release addr %s to pool: %w", addr, err))
 		}
 	}
 
@@ -120,6 +240,16 @@ func (m *PeerManager) Delete(v string) {
 	delete(m.m, v)
 }
 
+// PoolStats returns the utilization of each address pool of the PeerManager, in the order of the pools.
+func (m *PeerManager) PoolStats() []types.PoolStats {
+	items := make([]types.PoolStats, 0, len(m.pools))
+	for _, pool := range m.pools {
+		items = append(items, pool.Stats())
+	}
+
+	return items
+}
+
 // Len returns the number of Peers in the PeerManager.
 func (m *PeerManager) Len() int {
 	m.rwm.RLock()
@@ -1,8 +1,14 @@
 package wireguard
 
+import (
+	"errors"
+	"time"
+)
+
 // AddPeerRequest represents a request to add a new peer in WireGuard.
 type AddPeerRequest struct {
-	PublicKey *Key `json:"public_key"`
+	PublicKey *Key          `json:"public_key"`
+	Duration  time.Duration `json:"duration,omitempty"` // Lease of the peer's addresses, zero if they do not expire
 }
 
 // Key returns the public key as a string.
@@ -12,6 +18,10 @@ func (r *AddPeerRequest) Key() string {
 
 // Validate checks if the AddPeerRequest is valid.
 func (r *AddPeerRequest) Validate() error {
+	if r.Duration < 0 {
+		return errors.New("duration cannot be negative")
+	}
+
 	return nil
 }
 
@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
 	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
@@ -19,10 +21,11 @@ var _ types.ServerService = (*Server)(nil)
 
 // Server represents the WireGuard server instance.
 type Server struct {
-	homeDir  string            // Home directory of the WireGuard server.
-	metadata []*ServerMetadata // Metadata containing server-specific details.
-	name     string            // Name of the server instance.
-	pm       *PeerManager      // Peer manager for handling peer information.
+	homeDir      string            // Home directory of the WireGuard server.
+	metadata     []*ServerMetadata // Metadata containing server-specific details.
+	name         string            // Name of the server instance.
+	pm           *PeerManager      // Peer manager for handling peer information.
+	secureRemove bool              // Overwrite the configuration file before removing it.
 }
 
 // NewServer creates a new Server instance.
@@ -48,6 +51,22 @@ func (s *Server) WithPeerManager(pm *PeerManager) *Server {
 	return s
 }
 
+// WithSecureRemove sets whether the configuration file, which contains the private key, is overwritten
+// before it is removed in PostDown, and returns the updated Server instance.
+func (s *Server) WithSecureRemove(secureRemove bool) *Server {
+	s.secureRemove = secureRemove
+	return s
+}
+
+// removeFile removes the file at the specified path, overwriting it first if secure removal is enabled.
+func (s *Server) removeFile(path string) error {
+	if s.secureRemove {
+		return utils.RemoveFileSecure(path)
+	}
+
+	return utils.RemoveFile(path)
+}
+
 // configFilePath returns the file path of the server's configuration file.
 func (s *Server) configFilePath() string {
 	return filepath.Join(s.homeDir, fmt.Sprintf("%s.conf", s.name))
@@ -98,6 +117,11 @@ func (s *Server) PreUp(v interface{}) error {
 		return fmt.Errorf("invalid parameter type %T", v)
 	}
 
+	// Checks that the listening port is free, identifying the process using it otherwise.
+	if err := utils.CheckProtoPorts("udp", cfg.InPort()); err != nil {
+		return fmt.Errorf("failed to check ports: %w", err)
+	}
+
 	s.metadata = []*ServerMetadata{
 		{
 			Port:      cfg.OutPort(),
@@ -126,7 +150,7 @@ func (s *Server) PreDown() error {
 // PostDown performs cleanup operations after the server process is terminated.
 func (s *Server) PostDown() error {
 	// Removes configuration file.
-	if err := utils.RemoveFile(s.configFilePath()); err != nil {
+	if err := s.removeFile(s.configFilePath()); err != nil {
 		return fmt.Errorf("failed to remove config: %w", err)
 	}
 
@@ -147,8 +171,14 @@ func (s *Server) AddPeer(ctx context.Context, req interface{}) (res interface{},
 	// Retrieve the identity from the request.
 	identity := r.Key()
 
-	// Add peer to the peer manager and retrieve assigned IP addresses.
-	addrs, err := s.pm.Put(identity)
+	// Add peer to the peer manager and retrieve assigned IP addresses, reserving them
+	// for the lease duration if one is given.
+	var addrs []netip.Prefix
+	if r.Duration > 0 {
+		addrs, err = s.pm.putFor(identity, r.Duration)
+	} else {
+		addrs, err = s.pm.Put(identity)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to put peer: %w", err)
 	}
@@ -209,9 +239,11 @@ func (s *Server) RemovePeer(ctx context.Context, req interface{}) error {
 		return fmt.Errorf("invalid request: %w", err)
 	}
 
-	// Retrieve the identity from the request.
-	identity := r.Key()
+	return s.removePeer(ctx, r.Key())
+}
 
+// removePeer removes the peer with the given identity from the WireGuard interface and the peer manager.
+func (s *Server) removePeer(ctx context.Context, identity string) error {
 	// Executes the 'wg set' command to remove the peer from the WireGuard interface.
 	cmd := exec.CommandContext(
 		ctx,
@@ -229,11 +261,36 @@ func (s *Server) RemovePeer(ctx context.Context, req interface{}) error {
 	return nil
 }
 
+// RemoveExpiredPeers removes the peers whose address reservation has expired from the WireGuard server,
+// releasing their addresses. It is the only path evicting peers added with a lease duration or through the
+// PeerManager's ReserveFor, and is run periodically by the workers.PeerExpiry cron worker. It continues past
+// failed removals, returning the first error.
+func (s *Server) RemoveExpiredPeers(ctx context.Context) error {
+	var firstErr error
+	for _, identity := range s.pm.Expired() {
+		if err := s.removePeer(ctx, identity); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to remove peer %s: %w", identity, err)
+		}
+	}
+
+	return firstErr
+}
+
+// PoolStats returns the utilization of the IPv4 and IPv6 address pools of the WireGuard server,
+// for example to export as gauges and alert before the pools are exhausted.
+func (s *Server) PoolStats() []types.PoolStats {
+	return s.pm.PoolStats()
+}
+
 // PeerCount returns the number of peers connected to the WireGuard server.
 func (s *Server) PeerCount() int {
 	return s.pm.Len()
 }
 
+// connectedHandshakeWindow is the time since the latest handshake within which a peer is considered
+// connected. WireGuard renews sessions every two minutes and rejects them after three.
+const connectedHandshakeWindow = 3 * time.Minute
+
 // PeerStatistics retrieves statistics for each peer connected to the WireGuard server.
 func (s *Server) PeerStatistics(ctx context.Context) (items []*types.PeerStatistic, err error) {
 	// Retrieves the interface name.
@@ -242,45 +299,64 @@ func (s *Server) PeerStatistics(ctx context.Context) (items []*types.PeerStatist
 		return nil, fmt.Errorf("failed to get interface name: %w", err)
 	}
 
-	// Executes the 'wg show' command to get transfer statistics.
+	// Executes the 'wg show' command to dump the peer details and transfer statistics.
 	output, err := exec.CommandContext(
 		ctx,
 		s.execFile("wg"),
-		strings.Fields(fmt.Sprintf("show %s transfer", iface))...,
+		strings.Fields(fmt.Sprintf("show %s dump", iface))...,
 	).Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run command: %w", err)
 	}
 
-	// Split the command output into lines and process each line.
+	collectedAt := time.Now()
+
+	// Split the command output into lines and process each line. Peer lines have the columns public-key,
+	// preshared-key, endpoint, allowed-ips, latest-handshake, transfer-rx, transfer-tx and persistent-keepalive,
+	// while the first line describes the interface itself and is skipped.
 	lines := strings.Split(string(output), "\n")
 	for _, line := range lines {
 		columns := strings.Split(line, "\t")
-		if len(columns) != 3 {
+		if len(columns) != 8 {
 			continue
 		}
 
 		// Parse upload traffic stats.
-		uploadBytes, err := strconv.ParseInt(columns[1], 10, 64)
+		uploadBytes, err := strconv.ParseInt(columns[5], 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse upload bytes: %w", err)
 		}
 
 		// Parse download traffic stats.
-		downloadBytes, err := strconv.ParseInt(columns[2], 10, 64)
+		downloadBytes, err := strconv.ParseInt(columns[6], 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse download bytes: %w", err)
 		}
 
+		// Parse the latest handshake time, which is zero if no handshake has happened.
+		handshake, err := strconv.ParseInt(columns[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse latest handshake: %w", err)
+		}
+
+		item := &types.PeerStatistic{
+			Key:           columns[0],
+			CollectedAt:   collectedAt,
+			DownloadBytes: downloadBytes,
+			UploadBytes:   uploadBytes,
+		}
+
+		if columns[2] != "(none)" {
+			item.Endpoint = columns[2]
+		}
+		if handshake > 0 {
+			lastHandshakeAt := time.Unix(handshake, 0)
+			item.LastHandshakeAt = &lastHandshakeAt
+			item.Connected = collectedAt.Sub(lastHandshakeAt) < connectedHandshakeWindow
+		}
+
 		// Append peer statistics to the result collection.
-		items = append(
-			items,
-			&types.PeerStatistic{
-				Key:           columns[0],
-				DownloadBytes: downloadBytes,
-				UploadBytes:   uploadBytes,
-			},
-		)
+		items = append(items, item)
 	}
 
 	// Return the constructed collection of peer statistics.
@@ -4,23 +4,42 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"os"
 	"strings"
 
 	"github.com/spf13/pflag"
 
+	"github.com/qubetics/qubetics-go-sdk/config"
 	"github.com/qubetics/qubetics-go-sdk/types"
 	"github.com/qubetics/qubetics-go-sdk/utils"
 )
 
 // ServerConfig represents the WireGuard server configuration.
 type ServerConfig struct {
-	InInterface  string `mapstructure:"in_interface"`  // InInterface specifies the inbound interface.
-	IPv4Addr     string `mapstructure:"ipv4_addr"`     // IPv4Addr is the IPv4 address with CIDR notation.
-	IPv6Addr     string `mapstructure:"ipv6_addr"`     // IPv6Addr is the IPv6 address with CIDR notation.
-	OutInterface string `mapstructure:"out_interface"` // OutInterface specifies the outbound interface.
-	Port         string `mapstructure:"port"`          // Port specifies the WireGuard listening port.
-	PrivateKey   string `mapstructure:"private_key"`   // PrivateKey is the WireGuard private key.
+	InInterface  string `mapstructure:"in_interface"`   // InInterface specifies the inbound interface.
+	IPv4Addr     string `mapstructure:"ipv4_addr"`      // IPv4Addr is the IPv4 address with CIDR notation.
+	IPv6Addr     string `mapstructure:"ipv6_addr"`      // IPv6Addr is the IPv6 address with CIDR notation.
+	IPv6PeerBits int    `mapstructure:"ipv6_peer_bits"` // IPv6PeerBits is the prefix length assigned to each peer, 128 if zero.
+	OutInterface string `mapstructure:"out_interface"`  // OutInterface specifies the outbound interface.
+	Port         string `mapstructure:"port"`           // Port specifies the WireGuard listening port.
+	PrivateKey   string `mapstructure:"private_key"`    // PrivateKey is the WireGuard private key.
+}
+
+// Redacted returns a copy of the configuration with the private key masked.
+func (c *ServerConfig) Redacted() *ServerConfig {
+	v := *c
+	v.PrivateKey = config.RedactString(v.PrivateKey)
+
+	return &v
+}
+
+// RedactedSection returns the redacted configuration as a config.Section.
+func (c *ServerConfig) RedactedSection() config.Section {
+	return c.Redacted()
+}
+
+// String returns the redacted configuration encoded as JSON, so the private key never appears in logs.
+func (c *ServerConfig) String() string {
+	return string(utils.MustMarshalJSON(c.Redacted()))
 }
 
 // Address returns the combined IPv4 and IPv6 addresses, separated by a comma.
@@ -87,9 +106,20 @@ func (c *ServerConfig) Validate() error {
 
 	// Validate IPv6Addr if provided.
 	if c.IPv6Addr != "" {
-		if _, err := types.NewNetPrefixFromString(c.IPv6Addr); err != nil {
+		prefix, err := types.NewNetPrefixFromString(c.IPv6Addr)
+		if err != nil {
 			return fmt.Errorf("invalid ipv6_addr: %w", err)
 		}
+		if !prefix.Addr().Is6() {
+			return errors.New("ipv6_addr must be an ipv6 address")
+		}
+
+		// Ensure the prefix holds at least one peer block besides the server's own.
+		if c.IPv6PeerBits != 0 && (c.IPv6PeerBits <= prefix.Bits() || c.IPv6PeerBits > 128) {
+			return fmt.Errorf("ipv6_peer_bits must be between %d and 128", prefix.Bits()+1)
+		}
+	} else if c.IPv6PeerBits != 0 {
+		return errors.New("ipv6_peer_bits requires ipv6_addr")
 	}
 
 	// Ensure OutInterface is not empty.
@@ -124,16 +154,11 @@ func (c *ServerConfig) WriteToFile(name string) error {
 		return fmt.Errorf("failed to read template: %w", err)
 	}
 
-	// Execute the template and write it to the specified file.
-	if err := utils.ExecTemplateToFile(string(text), c, name); err != nil {
+	// Execute the template and write it to the specified file, readable and writable by the owner only.
+	if err := utils.ExecTemplateToFile(string(text), c, name, 0600); err != nil {
 		return fmt.Errorf("failed to execute template to file: %w", err)
 	}
 
-	// Change file permissions to read and write for the owner only.
-	if err := os.Chmod(name, 0600); err != nil {
-		return fmt.Errorf("failed to change file permissions: %w", err)
-	}
-
 	return nil
 }
 
@@ -147,9 +172,22 @@ func (c *ServerConfig) IPv4Pool() (*types.IPPool, error) {
 	return pool, nil
 }
 
-// IPv6Pool returns the IPv6 address pool.
+// IPv6Pool returns the IPv6 address pool, which assigns a block of IPv6PeerBits to each peer.
+//
+// Assigning /128 addresses maximizes the number of peers, while assigning shorter prefixes such as /112
+// delegates a routable range to each peer, for clients that expect one, at the cost of fewer peers per pool.
 func (c *ServerConfig) IPv6Pool() (*types.IPPool, error) {
-	pool, err := types.NewIPPoolFromString(c.IPv6Addr)
+	prefix, err := types.NewNetPrefixFromString(c.IPv6Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get net prefix: %w", err)
+	}
+
+	peerBits := c.IPv6PeerBits
+	if peerBits == 0 {
+		peerBits = 128
+	}
+
+	pool, err := types.NewIPPoolWithPeerBits(prefix, peerBits)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ip pool: %w", err)
 	}
@@ -201,7 +239,7 @@ func DefaultServerConfig() *ServerConfig {
 		IPv4Addr:     fmt.Sprintf("10.%d.%d.1/24", rand.Intn(256), rand.Intn(256)),
 		IPv6Addr:     "",
 		OutInterface: "eth0",
-		Port:         fmt.Sprintf("%d", utils.RandomPort()),
+		Port:         fmt.Sprintf("%d", utils.MustRandomFreePort("udp")),
 		PrivateKey:   pk.String(),
 	}
 }
@@ -45,3 +45,6 @@ func (s *Server) Up(ctx context.Context) error {
 
 	return nil
 }
+
+// serverBinaries are the executables run by the WireGuard server.
+var serverBinaries = []string{"wg", "wg-quick"}
@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/qubetics/qubetics-go-sdk/libs/cron"
+	"github.com/qubetics/qubetics-go-sdk/libs/log"
+)
+
+const (
+	// NamePeerExpiry is the name of the peer expiry worker.
+	NamePeerExpiry = "peer_expiry"
+)
+
+// ExpiredPeerRemover is implemented by VPN servers whose peers hold expiring address reservations,
+// such as wireguard.Server.
+type ExpiredPeerRemover interface {
+	RemoveExpiredPeers(ctx context.Context) error
+}
+
+// PeerExpiry periodically removes the peers whose address reservation has expired from a VPN server,
+// releasing their addresses to the pools.
+type PeerExpiry struct {
+	server ExpiredPeerRemover
+
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewPeerExpiry creates a new PeerExpiry with default settings.
+func NewPeerExpiry(server ExpiredPeerRemover) *PeerExpiry {
+	return &PeerExpiry{
+		server:   server,
+		interval: 1 * time.Minute,
+		timeout:  1 * time.Minute,
+	}
+}
+
+// WithInterval sets the interval between removals of expired peers.
+func (e *PeerExpiry) WithInterval(interval time.Duration) *PeerExpiry {
+	e.interval = interval
+	return e
+}
+
+// WithTimeout sets the timeout of a single removal run.
+func (e *PeerExpiry) WithTimeout(timeout time.Duration) *PeerExpiry {
+	e.timeout = timeout
+	return e
+}
+
+// Run removes the expired peers once.
+func (e *PeerExpiry) Run(ctx context.Context) error {
+	return e.server.RemoveExpiredPeers(ctx)
+}
+
+// Worker returns a cron worker that removes the expired peers at the configured interval.
+func (e *PeerExpiry) Worker() cron.Worker {
+	return cron.NewBasicWorker().
+		WithName(NamePeerExpiry).
+		WithInterval(e.interval).
+		WithHandler(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+			defer cancel()
+
+			return e.Run(ctx)
+		}).
+		WithOnError(func(err error) bool {
+			log.Error("Peer expiry failed", "error", err)
+			return false
+		})
+}
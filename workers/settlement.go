@@ -0,0 +1,297 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/qubetics/qubetics-go-sdk/core"
+	"github.com/qubetics/qubetics-go-sdk/libs/cron"
+	"github.com/qubetics/qubetics-go-sdk/libs/log"
+	"github.com/qubetics/qubetics-go-sdk/types"
+)
+
+const (
+	// NameSessionSettlement is the name of the session settlement worker.
+	NameSessionSettlement = "session_settlement"
+)
+
+// peerUsage tracks the accumulated usage of a peer across statistic collections.
+type peerUsage struct {
+	sessionID    uint64
+	download     int64 // Accumulated download bytes of the session.
+	upload       int64 // Accumulated upload bytes of the session.
+	lastDownload int64 // Download counter observed at the last collection.
+	lastUpload   int64 // Upload counter observed at the last collection.
+	firstSeenAt  time.Time
+	lastActiveAt time.Time // Time of the last collection in which the usage increased.
+	lastSeenAt   time.Time
+	dirty        bool // Indicates if the usage changed since it was last submitted.
+}
+
+// SessionSettlement periodically collects peer statistics from a VPN server, matches peers to
+// on-chain sessions and submits their usage. Sessions whose peers have been gone for longer
+// than the inactivity timeout are submitted one last time and ended.
+type SessionSettlement struct {
+	client  *core.Client
+	service types.ServerService
+
+	batchSize         int
+	inactiveAfter     time.Duration
+	interval          time.Duration
+	monotonic         bool
+	removeUnknownPeer func(ctx context.Context, key string) error
+	sessionForPeer    func(key string) (uint64, bool)
+	timeout           time.Duration
+
+	peers map[string]*peerUsage
+	mu    sync.Mutex
+}
+
+// NewSessionSettlement creates a new SessionSettlement with default settings.
+func NewSessionSettlement(client *core.Client, service types.ServerService) *SessionSettlement {
+	return &SessionSettlement{
+		client:        client,
+		service:       service,
+		batchSize:     25,
+		inactiveAfter: 5 * time.Minute,
+		interval:      1 * time.Minute,
+		monotonic:     true,
+		timeout:       1 * time.Minute,
+		peers:         make(map[string]*peerUsage),
+	}
+}
+
+// WithBatchSize sets the maximum number of session updates submitted in a single transaction.
+func (s *SessionSettlement) WithBatchSize(size int) *SessionSettlement {
+	s.batchSize = size
+	return s
+}
+
+// WithInactiveAfter sets the duration after which a session whose peer is no longer reported is ended.
+func (s *SessionSettlement) WithInactiveAfter(d time.Duration) *SessionSettlement {
+	s.inactiveAfter = d
+	return s
+}
+
+// WithInterval sets the interval between settlements.
+func (s *SessionSettlement) WithInterval(interval time.Duration) *SessionSettlement {
+	s.interval = interval
+	return s
+}
+
+// WithMonotonic sets whether peer statistics are cumulative counters (true) or deltas since the previous collection (false).
+// Cumulative counters that decrease, e.g. after a server restart, are treated as having been reset.
+func (s *SessionSettlement) WithMonotonic(monotonic bool) *SessionSettlement {
+	s.monotonic = monotonic
+	return s
+}
+
+// WithRemoveUnknownPeer sets the function used to remove peers without a matching on-chain session.
+// If it is not set, such peers are only logged.
+func (s *SessionSettlement) WithRemoveUnknownPeer(fn func(ctx context.Context, key string) error) *SessionSettlement {
+	s.removeUnknownPeer = fn
+	return s
+}
+
+// WithSessionForPeer sets the function that resolves the session ID of a peer key.
+func (s *SessionSettlement) WithSessionForPeer(fn func(key string) (uint64, bool)) *SessionSettlement {
+	s.sessionForPeer = fn
+	return s
+}
+
+// WithTimeout sets the timeout of a single settlement run.
+func (s *SessionSettlement) WithTimeout(timeout time.Duration) *SessionSettlement {
+	s.timeout = timeout
+	return s
+}
+
+// Worker returns a cron worker that runs the settlement at the configured interval.
+func (s *SessionSettlement) Worker() cron.Worker {
+	return cron.NewBasicWorker().
+		WithName(NameSessionSettlement).
+		WithInterval(s.interval).
+		WithHandler(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+			defer cancel()
+
+			return s.Run(ctx)
+		}).
+		WithOnError(func(err error) bool {
+			log.Error("Session settlement failed", "error", err)
+			return false
+		})
+}
+
+// Run performs a single settlement: it collects peer statistics, submits the usage of matched
+// sessions and ends sessions whose peers have been inactive. A failure for one peer does not stop
+// the others from being processed, since statistics read in reset mode would otherwise be lost;
+// the errors are joined and returned once the run completes.
+func (s *SessionSettlement) Run(ctx context.Context) error {
+	if s.sessionForPeer == nil {
+		return errors.New("session for peer func is not set")
+	}
+
+	items, err := s.service.PeerStatistics(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get peer statistics: %w", err)
+	}
+
+	var errs []error
+
+	now := time.Now()
+	for _, item := range items {
+		if err := s.collect(ctx, item, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Sessions whose update failed are kept, so that their usage is submitted again before they are ended.
+	failed := make(map[uint64]bool)
+
+	updates, ended := s.pending(now)
+	for start := 0; start < len(updates); start += max(s.batchSize, 1) {
+		end := min(start+max(s.batchSize, 1), len(updates))
+		if err := s.client.UpdateSessions(ctx, updates[start:end]...); err != nil {
+			s.markDirty(updates[start:end])
+			for _, u := range updates[start:end] {
+				failed[u.ID] = true
+			}
+
+			errs = append(errs, fmt.Errorf("failed to update sessions: %w", err))
+		}
+	}
+
+	for _, key := range ended {
+		if err := s.end(ctx, key, failed); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collect accumulates the statistic of a peer, verifying that it has a matching on-chain session.
+func (s *SessionSettlement) collect(ctx context.Context, item *types.PeerStatistic, now time.Time) error {
+	s.mu.Lock()
+	usage, ok := s.peers[item.Key]
+	s.mu.Unlock()
+
+	if !ok {
+		id, found := s.sessionForPeer(item.Key)
+		if found {
+			session, err := s.client.Session(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to query session %d: %w", id, err)
+			}
+
+			found = session != nil
+		}
+
+		if !found {
+			log.Warn("Peer has no matching on-chain session", "key", item.Key)
+			if s.removeUnknownPeer != nil {
+				if err := s.removeUnknownPeer(ctx, item.Key); err != nil {
+					return fmt.Errorf("failed to remove peer %s: %w", item.Key, err)
+				}
+			}
+
+			return nil
+		}
+
+		usage = &peerUsage{sessionID: id, firstSeenAt: now, lastActiveAt: now}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var download, upload int64
+	if s.monotonic {
+		download = types.CounterDelta(usage.lastDownload, item.DownloadBytes)
+		upload = types.CounterDelta(usage.lastUpload, item.UploadBytes)
+		usage.lastDownload, usage.lastUpload = item.DownloadBytes, item.UploadBytes
+	} else {
+		download = max(item.DownloadBytes, 0)
+		upload = max(item.UploadBytes, 0)
+	}
+
+	// Only a change of the usage needs to be submitted, so idle sessions do not cost a transaction
+	// on every run. The duration of a session is the time during which its usage increased.
+	if download > 0 || upload > 0 {
+		usage.download += download
+		usage.upload += upload
+		usage.lastActiveAt = now
+		usage.dirty = true
+	}
+
+	usage.lastSeenAt = now
+	s.peers[item.Key] = usage
+
+	return nil
+}
+
+// pending returns the session updates to submit and the keys of peers whose sessions should be ended.
+func (s *SessionSettlement) pending(now time.Time) (updates []core.SessionUpdate, ended []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, usage := range s.peers {
+		if usage.dirty {
+			usage.dirty = false
+			updates = append(updates, core.SessionUpdate{
+				ID:       usage.sessionID,
+				Download: usage.download,
+				Upload:   usage.upload,
+				Duration: usage.lastActiveAt.Sub(usage.firstSeenAt),
+			})
+		}
+
+		if now.Sub(usage.lastSeenAt) > s.inactiveAfter {
+			ended = append(ended, key)
+		}
+	}
+
+	return updates, ended
+}
+
+// markDirty marks the usage of the given sessions to be submitted again on the next run.
+func (s *SessionSettlement) markDirty(updates []core.SessionUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[uint64]bool, len(updates))
+	for _, u := range updates {
+		ids[u.ID] = true
+	}
+
+	for _, usage := range s.peers {
+		if ids[usage.sessionID] {
+			usage.dirty = true
+		}
+	}
+}
+
+// end ends the session of the peer on-chain and stops tracking it, unless the last update of the
+// session failed, in which case it is ended on a later run once its usage has been submitted.
+func (s *SessionSettlement) end(ctx context.Context, key string, failed map[uint64]bool) error {
+	s.mu.Lock()
+	usage, ok := s.peers[key]
+	s.mu.Unlock()
+
+	if !ok || failed[usage.sessionID] {
+		return nil
+	}
+
+	if err := s.client.EndSession(ctx, usage.sessionID); err != nil {
+		return fmt.Errorf("failed to end session %d: %w", usage.sessionID, err)
+	}
+
+	s.mu.Lock()
+	delete(s.peers, key)
+	s.mu.Unlock()
+
+	log.Info("Ended inactive session", "id", usage.sessionID, "key", key)
+	return nil
+}